@@ -0,0 +1,39 @@
+package assets
+
+// AsActor attributes the node being inserted to the given actor, e.g.
+//
+//	g.AddNode("vm-1", "host", body, assets.AsActor("scanner-aws"))
+//
+// It overrides the ambient actor set with SetActor. Updates stamp UpdatedBy
+// from the ambient actor.
+func AsActor(actor string) NodeOption {
+	return func(n *Node) {
+		n.CreatedBy = actor
+		n.UpdatedBy = actor
+	}
+}
+
+// AsRelActor attributes the relationship being added to the given actor,
+// overriding the ambient actor set with SetActor.
+func AsRelActor(actor string) RelOption {
+	return func(r *Relationship) {
+		r.CreatedBy = actor
+		r.UpdatedBy = actor
+	}
+}
+
+// FilterNodesByActor keeps nodes created or last updated by the given actor,
+// so the assertions of one discovery source can be inspected in isolation.
+func FilterNodesByActor(actor string) Filter {
+	return func(n Node) bool {
+		return n.CreatedBy == actor || n.UpdatedBy == actor
+	}
+}
+
+// FilterRelsByActor keeps relationships created or last updated by the given
+// actor.
+func FilterRelsByActor(actor string) FilterRelationship {
+	return func(r Relationship) bool {
+		return r.CreatedBy == actor || r.UpdatedBy == actor
+	}
+}
@@ -0,0 +1,185 @@
+// Package analytics ranks the nodes of an asset graph by structural
+// importance. All functions operate on the read-only graph surface, so they
+// can run against a live graph, a snapshot or an as-of view alike.
+package analytics
+
+import (
+	"sort"
+
+	"github.com/mimatache/asset-graph/assets"
+)
+
+// DegreeCentrality returns, per node ID, the fraction of other nodes each
+// node is directly connected to (in either direction). Scores range from 0
+// for isolated nodes to 1 for a node touching everything.
+func DegreeCentrality(g assets.ReadOnlyGraph) map[string]float64 {
+	ids, adjacency, _ := topology(g)
+	scores := make(map[string]float64, len(ids))
+	if len(ids) < 2 {
+		for _, id := range ids {
+			scores[id] = 0
+		}
+		return scores
+	}
+	for _, id := range ids {
+		neighbours := map[string]bool{}
+		for _, to := range adjacency[id] {
+			neighbours[to] = true
+		}
+		for _, other := range ids {
+			for _, to := range adjacency[other] {
+				if to == id {
+					neighbours[other] = true
+				}
+			}
+		}
+		delete(neighbours, id)
+		scores[id] = float64(len(neighbours)) / float64(len(ids)-1)
+	}
+	return scores
+}
+
+// PageRank returns the PageRank score of every node, following relationships
+// in the From->To direction with the standard damping factor of 0.85. Scores
+// sum to 1; higher means more assets transitively depend on the node.
+func PageRank(g assets.ReadOnlyGraph) map[string]float64 {
+	const (
+		damping    = 0.85
+		iterations = 100
+		epsilon    = 1e-9
+	)
+	ids, adjacency, _ := topology(g)
+	n := len(ids)
+	if n == 0 {
+		return map[string]float64{}
+	}
+	ranks := make(map[string]float64, n)
+	for _, id := range ids {
+		ranks[id] = 1 / float64(n)
+	}
+	for i := 0; i < iterations; i++ {
+		next := make(map[string]float64, n)
+		dangling := 0.0
+		for _, id := range ids {
+			if len(adjacency[id]) == 0 {
+				dangling += ranks[id]
+			}
+		}
+		base := (1-damping)/float64(n) + damping*dangling/float64(n)
+		for _, id := range ids {
+			next[id] = base
+		}
+		for _, id := range ids {
+			if len(adjacency[id]) == 0 {
+				continue
+			}
+			share := damping * ranks[id] / float64(len(adjacency[id]))
+			for _, to := range adjacency[id] {
+				next[to] += share
+			}
+		}
+		delta := 0.0
+		for _, id := range ids {
+			diff := next[id] - ranks[id]
+			if diff < 0 {
+				diff = -diff
+			}
+			delta += diff
+		}
+		ranks = next
+		if delta < epsilon {
+			break
+		}
+	}
+	return ranks
+}
+
+// BetweennessCentrality returns, per node ID, how often the node sits on a
+// shortest path between two other nodes, computed with Brandes' algorithm
+// over directed, unweighted edges. Chokepoint assets score highest.
+func BetweennessCentrality(g assets.ReadOnlyGraph) map[string]float64 {
+	ids, adjacency, _ := topology(g)
+	scores := make(map[string]float64, len(ids))
+	for _, id := range ids {
+		scores[id] = 0
+	}
+	for _, source := range ids {
+		// Single-source shortest-path counts.
+		order := []string{}
+		predecessors := map[string][]string{}
+		pathCount := map[string]float64{source: 1}
+		distance := map[string]int{source: 0}
+		queue := []string{source}
+		for len(queue) > 0 {
+			current := queue[0]
+			queue = queue[1:]
+			order = append(order, current)
+			for _, to := range adjacency[current] {
+				if _, seen := distance[to]; !seen {
+					distance[to] = distance[current] + 1
+					queue = append(queue, to)
+				}
+				if distance[to] == distance[current]+1 {
+					pathCount[to] += pathCount[current]
+					predecessors[to] = append(predecessors[to], current)
+				}
+			}
+		}
+		// Back-propagate dependencies.
+		dependency := map[string]float64{}
+		for i := len(order) - 1; i >= 0; i-- {
+			current := order[i]
+			for _, predecessor := range predecessors[current] {
+				dependency[predecessor] += pathCount[predecessor] / pathCount[current] * (1 + dependency[current])
+			}
+			if current != source {
+				scores[current] += dependency[current]
+			}
+		}
+	}
+	return scores
+}
+
+// WeightedDegreeCentrality returns, per node ID, the sum of the weights of
+// the relationships touching each node in either direction. Unweighted
+// relationships count as weight 1, so on an unweighted graph this is the
+// plain degree.
+func WeightedDegreeCentrality(g assets.ReadOnlyGraph) map[string]float64 {
+	ids, _, rels := topology(g)
+	scores := make(map[string]float64, len(ids))
+	for _, id := range ids {
+		scores[id] = 0
+	}
+	for _, rel := range rels {
+		weight := assets.StoredWeight(rel)
+		if _, ok := scores[rel.From]; ok {
+			scores[rel.From] += weight
+		}
+		if _, ok := scores[rel.To]; ok {
+			scores[rel.To] += weight
+		}
+	}
+	return scores
+}
+
+// topology extracts the node IDs and the outgoing adjacency of the graph
+// once, so each algorithm works on plain maps instead of holding graph locks
+// across iterations.
+func topology(g assets.ReadOnlyGraph) (ids []string, adjacency map[string][]string, rels []assets.Relationship) {
+	nodes := g.ListNodes()
+	ids = make([]string, 0, len(nodes))
+	adjacency = map[string][]string{}
+	known := map[string]bool{}
+	for _, node := range nodes {
+		ids = append(ids, node.ID)
+		known[node.ID] = true
+	}
+	sort.Strings(ids)
+	rels = g.ListRelationships()
+	for _, rel := range rels {
+		if known[rel.From] && known[rel.To] {
+			adjacency[rel.From] = append(adjacency[rel.From], rel.To)
+		}
+	}
+	return ids, adjacency, rels
+}
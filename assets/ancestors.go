@@ -0,0 +1,33 @@
+package assets
+
+import "sort"
+
+// CommonAncestors returns the nodes reachable from both given nodes along
+// outgoing relationships — the shared infrastructure two services ultimately
+// depend on. With labels given, only relationships carrying one of those
+// labels are followed. Neither input node is part of the result unless the
+// other can reach it. Results are ordered by node ID.
+func (g *Graph) CommonAncestors(a, b Node, relLabels ...string) []Node {
+	opts := []TraversalOption{}
+	if len(relLabels) > 0 {
+		labelFilters := make([]FilterRelationship, 0, len(relLabels))
+		for _, label := range relLabels {
+			labelFilters = append(labelFilters, FilterRelByLabel(label))
+		}
+		opts = append(opts, WithRelFilters(OrRel(labelFilters...)))
+	}
+	fromA := g.Reachable(a.ID, opts...)
+	fromB := g.Reachable(b.ID, opts...)
+	reachableFromB := make(map[string]bool, len(fromB))
+	for _, node := range fromB {
+		reachableFromB[node.ID] = true
+	}
+	shared := []Node{}
+	for _, node := range fromA {
+		if reachableFromB[node.ID] {
+			shared = append(shared, node)
+		}
+	}
+	sort.Slice(shared, func(i, j int) bool { return shared[i].ID < shared[j].ID })
+	return shared
+}
@@ -0,0 +1,92 @@
+package assets
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// RenderPath renders a chain as an indented tree for terminals and logs,
+// one node per line with the relationship that led to it:
+//
+//	api (service)
+//	└─ depends_on → db (database)
+//	   └─ runs_on → host-1 (machine)
+//
+// It is a multi-line alternative to ChainLink.String, which compresses the
+// same chain onto one line.
+func RenderPath(chain *ChainLink) string {
+	if chain == nil {
+		return ""
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s (%s)\n", chain.node.Name, chain.node.Label)
+	renderLinks(&b, chain.next, "")
+	return b.String()
+}
+
+// renderLinks writes each link's subtree using box-drawing connectors, with
+// the prefix carrying the vertical guides of enclosing levels.
+func renderLinks(b *strings.Builder, links []*ChainLink, prefix string) {
+	for i, link := range links {
+		connector, childPrefix := "├─", prefix+"│  "
+		if i == len(links)-1 {
+			connector, childPrefix = "└─", prefix+"   "
+		}
+		label := link.rel.Label
+		if label == "" {
+			label = "->"
+		} else {
+			label += " →"
+		}
+		fmt.Fprintf(b, "%s%s %s %s (%s)\n", prefix, connector, label, link.node.Name, link.node.Label)
+		renderLinks(b, link.next, childPrefix)
+	}
+}
+
+// RenderTree renders the outgoing neighborhood of a node as an indented tree
+// down to the given depth, in the same format as RenderPath. Nodes already
+// shown on the current branch are marked as cycles rather than expanded
+// again. A depth below zero means no limit.
+func (g *Graph) RenderTree(root Node, depth int) string {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s (%s)\n", root.Name, root.Label)
+	g.renderTree(&b, root.ID, depth, "", map[string]bool{root.ID: true})
+	return b.String()
+}
+
+func (g *Graph) renderTree(b *strings.Builder, nodeID string, depth int, prefix string, onPath map[string]bool) {
+	if depth == 0 {
+		return
+	}
+	edges := g.edgesFrom(nodeID, traversalOptions{direction: Outgoing})
+	sort.Slice(edges, func(i, j int) bool {
+		if g.nodes[edges[i].to].Name != g.nodes[edges[j].to].Name {
+			return g.nodes[edges[i].to].Name < g.nodes[edges[j].to].Name
+		}
+		return edges[i].rel.ID < edges[j].rel.ID
+	})
+	for i, edge := range edges {
+		connector, childPrefix := "├─", prefix+"│  "
+		if i == len(edges)-1 {
+			connector, childPrefix = "└─", prefix+"   "
+		}
+		target := g.nodes[edge.to]
+		label := edge.rel.Label
+		if label == "" {
+			label = "->"
+		} else {
+			label += " →"
+		}
+		if onPath[edge.to] {
+			fmt.Fprintf(b, "%s%s %s %s (%s) [cycle]\n", prefix, connector, label, target.Name, target.Label)
+			continue
+		}
+		fmt.Fprintf(b, "%s%s %s %s (%s)\n", prefix, connector, label, target.Name, target.Label)
+		onPath[edge.to] = true
+		g.renderTree(b, edge.to, depth-1, childPrefix, onPath)
+		delete(onPath, edge.to)
+	}
+}
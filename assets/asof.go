@@ -0,0 +1,109 @@
+package assets
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrHistoryDisabled is returned by AsOf when the graph was not created with
+// WithHistory.
+var ErrHistoryDisabled = errors.New("history is not enabled on this graph")
+
+// GraphView is a read-only view of a graph, exposing queries but no
+// mutators.
+type GraphView struct {
+	graph *Graph
+}
+
+// GetNodeByID returns the node with the given ID from the view.
+func (v GraphView) GetNodeByID(id string) (Node, error) {
+	return v.graph.GetNodeByID(id)
+}
+
+// GetNodesByName returns every node in the view with the given name.
+func (v GraphView) GetNodesByName(name string) []Node {
+	return v.graph.GetNodesByName(name)
+}
+
+// ListNodes returns every node in the view matching the given filters.
+func (v GraphView) ListNodes(filters ...Filter) []Node {
+	return v.graph.ListNodes(filters...)
+}
+
+// ListRelationships returns every relationship in the view matching the
+// given filters.
+func (v GraphView) ListRelationships(filters ...FilterRelationship) []Relationship {
+	return v.graph.ListRelationships(filters...)
+}
+
+// ListConnections walks the view starting from the given node.
+func (v GraphView) ListConnections(start Node, opts ...TraversalOption) (*ChainLink, error) {
+	return v.graph.ListConnections(start, opts...)
+}
+
+// Neighbors returns the nodes directly connected to the given node in the
+// view.
+func (v GraphView) Neighbors(nodeID string, opts ...TraversalOption) []Node {
+	return v.graph.Neighbors(nodeID, opts...)
+}
+
+// AsOf reconstructs the graph as it existed at the given time, using the
+// revision history to roll node bodies back and the element timestamps to
+// drop nodes and relationships that did not exist yet. The graph must have
+// been created with WithHistory. Elements hard-deleted since t cannot be
+// resurrected, and revisions trimmed by the history limit reconstruct to the
+// oldest revision still retained.
+func (g *Graph) AsOf(t time.Time) (GraphView, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	if !g.historyEnabled {
+		return GraphView{}, ErrHistoryDisabled
+	}
+	view := NewGraph()
+	for _, node := range g.nodes {
+		if node.CreatedAt.After(t) {
+			continue
+		}
+		view.putNode(g.nodeAsOf(node, t))
+	}
+	for _, rel := range g.relationships {
+		if rel.CreatedAt.After(t) {
+			continue
+		}
+		if _, ok := view.nodes[rel.From]; !ok {
+			continue
+		}
+		if _, ok := view.nodes[rel.To]; !ok {
+			continue
+		}
+		view.putRelationship(rel)
+	}
+	return GraphView{graph: view}, nil
+}
+
+// nodeAsOf rolls a node back to the newest recorded state not younger than
+// t. Callers must hold at least a read lock.
+func (g *Graph) nodeAsOf(node Node, t time.Time) Node {
+	if node.DeletedAt != nil && node.DeletedAt.After(t) {
+		// The soft delete happened after t, so the node was still alive.
+		node.DeletedAt = nil
+	}
+	if !node.UpdatedAt.After(t) {
+		return node
+	}
+	revisions := g.history[node.ID]
+	for i := len(revisions) - 1; i >= 0; i-- {
+		if !revisions[i].UpdatedAt.After(t) {
+			node.Body = revisions[i].Body
+			node.Version = revisions[i].Version
+			node.UpdatedAt = revisions[i].UpdatedAt
+			return node
+		}
+	}
+	if len(revisions) > 0 {
+		node.Body = revisions[0].Body
+		node.Version = revisions[0].Version
+		node.UpdatedAt = revisions[0].UpdatedAt
+	}
+	return node
+}
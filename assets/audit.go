@@ -0,0 +1,113 @@
+package assets
+
+import "time"
+
+// AuditEntry records one committed mutation: when it happened, who asserted
+// it, and what changed. Node and Relationship carry the state written by the
+// mutation; for deletions only the ID remains.
+type AuditEntry struct {
+	Time           time.Time     `json:"time"`
+	Actor          string        `json:"actor,omitempty"`
+	Action         string        `json:"action"`
+	NodeID         string        `json:"node_id,omitempty"`
+	RelationshipID string        `json:"relationship_id,omitempty"`
+	Node           *Node         `json:"node,omitempty"`
+	Relationship   *Relationship `json:"relationship,omitempty"`
+}
+
+// AuditFilter narrows down the entries returned by AuditLog.
+type AuditFilter func(AuditEntry) bool
+
+// AuditSince keeps entries recorded at or after the given time.
+func AuditSince(t time.Time) AuditFilter {
+	return func(e AuditEntry) bool {
+		return !e.Time.Before(t)
+	}
+}
+
+// AuditByActor keeps entries asserted by the given actor.
+func AuditByActor(actor string) AuditFilter {
+	return func(e AuditEntry) bool {
+		return e.Actor == actor
+	}
+}
+
+// AuditByNode keeps entries touching the node with the given ID.
+func AuditByNode(id string) AuditFilter {
+	return func(e AuditEntry) bool {
+		return e.NodeID == id
+	}
+}
+
+// WithAudit makes the graph record every committed mutation into an
+// append-only trail queryable with AuditLog. Mutations inside a transaction
+// are recorded only once the transaction commits.
+func WithAudit() GraphOption {
+	return func(g *Graph) {
+		g.auditEnabled = true
+	}
+}
+
+// SetActor sets the ambient actor stamped on subsequently recorded audit
+// entries, e.g. the identity of the discovery run driving the mutations. See
+// AsActor for attributing a single mutation.
+func (g *Graph) SetActor(actor string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.actor = actor
+}
+
+// AuditLog returns the recorded mutations matching all of the given filters,
+// oldest first.
+func (g *Graph) AuditLog(filters ...AuditFilter) []AuditEntry {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	entries := []AuditEntry{}
+	for _, entry := range g.audit {
+		matched := true
+		for _, filter := range filters {
+			if !filter(entry) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// recordAudit appends an audit entry for a WAL-level mutation. It is called
+// from logMutation once an entry is past the transaction buffer, so
+// rolled-back mutations never appear in the trail. Callers must hold the
+// write lock.
+func (g *Graph) recordAudit(entry walEntry) {
+	if !g.auditEnabled {
+		return
+	}
+	record := AuditEntry{
+		Time:   now(),
+		Actor:  g.actor,
+		Action: entry.Op,
+		NodeID: entry.ID,
+	}
+	if entry.Node != nil {
+		node := *entry.Node
+		record.Node = &node
+		record.NodeID = node.ID
+		if node.UpdatedBy != "" {
+			record.Actor = node.UpdatedBy
+		}
+	}
+	if entry.Relationship != nil {
+		rel := *entry.Relationship
+		record.Relationship = &rel
+		record.RelationshipID = rel.ID
+		record.NodeID = ""
+		if rel.UpdatedBy != "" {
+			record.Actor = rel.UpdatedBy
+		}
+	}
+	g.audit = append(g.audit, record)
+}
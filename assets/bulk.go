@@ -0,0 +1,46 @@
+package assets
+
+// DeleteNodesWhere deletes every visible node matching all of the given
+// filters under a single lock acquisition, returning how many nodes were
+// removed. Delete options apply to each node the same way they do for
+// DeleteNode; with DeleteRestrict the first node that still has
+// relationships aborts the operation, leaving earlier deletions in place.
+func (g *Graph) DeleteNodesWhere(filters []Filter, opts ...DeleteOption) (int, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	deleted := 0
+	for _, node := range sortedNodes(g.nodes) {
+		if !g.nodeVisible(node) || !matchesNode(node, filters) {
+			continue
+		}
+		if err := g.deleteNode(node.ID, opts); err != nil {
+			return deleted, err
+		}
+		deleted++
+	}
+	return deleted, nil
+}
+
+// UpdateNodesWhere rewrites the body of every visible node matching all of
+// the given filters under a single lock acquisition. The function receives
+// each matching node and returns its new body; returning nil leaves that
+// node untouched. It returns how many nodes were updated.
+func (g *Graph) UpdateNodesWhere(filters []Filter, update func(Node) []byte) (int, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	updated := 0
+	for _, node := range sortedNodes(g.nodes) {
+		if !g.nodeVisible(node) || !matchesNode(node, filters) {
+			continue
+		}
+		body := update(node)
+		if body == nil {
+			continue
+		}
+		if _, err := g.updateNode(node.ID, body); err != nil {
+			return updated, err
+		}
+		updated++
+	}
+	return updated, nil
+}
@@ -0,0 +1,179 @@
+// Package cdc streams every graph mutation as a change event to a message
+// broker, so downstream systems such as CMDBs can stay in sync. The broker
+// is abstracted behind the Publisher interface: wire it to a Kafka producer,
+// a NATS connection, or anything else that can deliver one event.
+package cdc
+
+import (
+	"context"
+	"time"
+
+	"github.com/mimatache/asset-graph/assets"
+)
+
+// Action names the kind of change an event describes.
+type Action string
+
+const (
+	NodeInserted        Action = "node_inserted"
+	NodeUpdated         Action = "node_updated"
+	NodeDeleted         Action = "node_deleted"
+	RelationshipAdded   Action = "relationship_added"
+	RelationshipUpdated Action = "relationship_updated"
+	RelationshipDeleted Action = "relationship_deleted"
+)
+
+// Event is one graph change, carrying the state before and after it. Before
+// is nil for inserts and for elements last written before the stream was
+// attached; After is nil for deletions.
+type Event struct {
+	Action    Action               `json:"action"`
+	Time      time.Time            `json:"time"`
+	Before    *assets.Node         `json:"before,omitempty"`
+	After     *assets.Node         `json:"after,omitempty"`
+	RelBefore *assets.Relationship `json:"rel_before,omitempty"`
+	RelAfter  *assets.Relationship `json:"rel_after,omitempty"`
+}
+
+// Publisher delivers one event to the broker. Implementations wrap a Kafka
+// producer, a NATS connection, and so on; they are called from a single
+// goroutine, in mutation order.
+type Publisher interface {
+	Publish(ctx context.Context, event Event) error
+}
+
+// Option configures an attached stream.
+type Option func(*Stream)
+
+// WithBufferSize sets how many events may queue between the graph and the
+// publisher before mutations block. The default is 256.
+func WithBufferSize(n int) Option {
+	return func(s *Stream) {
+		s.buffer = n
+	}
+}
+
+// WithErrorHandler sets the function invoked when the publisher fails to
+// deliver an event. Without a handler failed events are dropped.
+func WithErrorHandler(fn func(Event, error)) Option {
+	return func(s *Stream) {
+		s.onError = fn
+	}
+}
+
+// Stream pumps graph mutations to a publisher until closed.
+type Stream struct {
+	publisher Publisher
+	buffer    int
+	onError   func(Event, error)
+	events    chan Event
+	done      chan struct{}
+	// known mirrors the last published state per element so update and
+	// delete events can carry a before image. Hooks run serially under the
+	// graph lock, so no further synchronization is needed.
+	knownNodes map[string]assets.Node
+	knownRels  map[string]assets.Relationship
+}
+
+// Attach registers mutation hooks on the graph and starts a goroutine that
+// forwards every change to the publisher. Events are published in mutation
+// order; call Close to stop and drain the stream.
+func Attach(g *assets.Graph, publisher Publisher, opts ...Option) *Stream {
+	stream := &Stream{
+		publisher:  publisher,
+		buffer:     256,
+		knownNodes: map[string]assets.Node{},
+		knownRels:  map[string]assets.Relationship{},
+	}
+	for _, opt := range opts {
+		opt(stream)
+	}
+	stream.events = make(chan Event, stream.buffer)
+	stream.done = make(chan struct{})
+	g.OnAfterInsert(func(node assets.Node) {
+		stream.knownNodes[node.ID] = node
+		stream.emit(Event{Action: NodeInserted, After: &node})
+	})
+	g.OnAfterUpdate(func(node assets.Node) {
+		event := Event{Action: NodeUpdated, After: &node}
+		if before, ok := stream.knownNodes[node.ID]; ok {
+			event.Before = &before
+		}
+		stream.knownNodes[node.ID] = node
+		stream.emit(event)
+	})
+	g.OnAfterDelete(func(node assets.Node) {
+		event := Event{Action: NodeDeleted}
+		if before, ok := stream.knownNodes[node.ID]; ok {
+			event.Before = &before
+		} else {
+			event.Before = &node
+		}
+		delete(stream.knownNodes, node.ID)
+		stream.emit(event)
+	})
+	g.OnAfterAddRelationship(func(rel assets.Relationship) {
+		stream.knownRels[rel.ID] = rel
+		stream.emit(Event{Action: RelationshipAdded, RelAfter: &rel})
+	})
+	g.OnAfterUpdateRelationship(func(rel assets.Relationship) {
+		event := Event{Action: RelationshipUpdated, RelAfter: &rel}
+		if before, ok := stream.knownRels[rel.ID]; ok {
+			event.RelBefore = &before
+		}
+		stream.knownRels[rel.ID] = rel
+		stream.emit(event)
+	})
+	g.OnAfterDeleteRelationship(func(rel assets.Relationship) {
+		event := Event{Action: RelationshipDeleted}
+		if before, ok := stream.knownRels[rel.ID]; ok {
+			event.RelBefore = &before
+		} else {
+			event.RelBefore = &rel
+		}
+		delete(stream.knownRels, rel.ID)
+		stream.emit(event)
+	})
+	go stream.run()
+	return stream
+}
+
+// emit queues an event for publication, stamping its time.
+func (s *Stream) emit(event Event) {
+	event.Time = time.Now()
+	select {
+	case s.events <- event:
+	case <-s.done:
+	}
+}
+
+// run forwards queued events to the publisher until Close is called.
+func (s *Stream) run() {
+	for {
+		select {
+		case event := <-s.events:
+			s.publish(event)
+		case <-s.done:
+			for {
+				select {
+				case event := <-s.events:
+					s.publish(event)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+func (s *Stream) publish(event Event) {
+	if err := s.publisher.Publish(context.Background(), event); err != nil && s.onError != nil {
+		s.onError(event, err)
+	}
+}
+
+// Close stops the stream after draining the events already queued. The hooks
+// stay registered but become no-ops.
+func (s *Stream) Close() {
+	close(s.done)
+}
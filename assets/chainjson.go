@@ -0,0 +1,64 @@
+package assets
+
+import "encoding/json"
+
+// PathStep is the flat, serializable form of one link in a chain: the node
+// reached and the relationship followed to reach it (nil on the first step).
+type PathStep struct {
+	Node         Node          `json:"node"`
+	Relationship *Relationship `json:"relationship,omitempty"`
+}
+
+// Steps flattens a linear chain — as returned by the path searches — into a
+// slice of steps. On branching chains the first branch is followed.
+func (c *ChainLink) Steps() []PathStep {
+	steps := []PathStep{}
+	for link := c; link != nil; {
+		step := PathStep{Node: link.node}
+		if link.rel.ID != "" {
+			rel := link.rel
+			step.Relationship = &rel
+		}
+		steps = append(steps, step)
+		if len(link.next) == 0 {
+			break
+		}
+		link = link.next[0]
+	}
+	return steps
+}
+
+// chainLinkJSON is the wire form of a ChainLink, mirroring its unexported
+// fields.
+type chainLinkJSON struct {
+	Node         Node          `json:"node"`
+	Relationship *Relationship `json:"relationship,omitempty"`
+	Next         []*ChainLink  `json:"next,omitempty"`
+}
+
+// MarshalJSON serializes the chain as nested objects so traversal results
+// can be returned from APIs and rendered in UIs.
+func (c *ChainLink) MarshalJSON() ([]byte, error) {
+	out := chainLinkJSON{Node: c.node, Next: c.next}
+	if c.rel.ID != "" {
+		rel := c.rel
+		out.Relationship = &rel
+	}
+	return json.Marshal(out)
+}
+
+// UnmarshalJSON restores a chain serialized by MarshalJSON.
+func (c *ChainLink) UnmarshalJSON(data []byte) error {
+	var in chainLinkJSON
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+	c.node = in.Node
+	c.next = in.Next
+	if in.Relationship != nil {
+		c.rel = *in.Relationship
+	} else {
+		c.rel = Relationship{}
+	}
+	return nil
+}
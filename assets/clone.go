@@ -0,0 +1,69 @@
+package assets
+
+// CloneOption configures Clone.
+type CloneOption func(*cloneOptions)
+
+type cloneOptions struct {
+	newIDs bool
+}
+
+// WithNewIDs makes Clone assign fresh IDs to every copied node and
+// relationship, rewriting endpoints accordingly, so the clone can be merged
+// back without colliding with the original.
+func WithNewIDs() CloneOption {
+	return func(o *cloneOptions) {
+		o.newIDs = true
+	}
+}
+
+// Clone returns a deep copy of the graph: nodes, relationships and bodies
+// are all duplicated, so the copy can be mutated freely without affecting
+// the original. IDs are preserved unless WithNewIDs is given.
+func (g *Graph) Clone(opts ...CloneOption) *Graph {
+	options := cloneOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	clone := NewGraph()
+	idMap := make(map[string]string, len(g.nodes))
+	for id, node := range g.nodes {
+		copied := node
+		copied.Body = append([]byte(nil), node.Body...)
+		if node.Properties != nil {
+			copied.Properties = make(map[string]any, len(node.Properties))
+			for key, value := range node.Properties {
+				copied.Properties[key] = value
+			}
+		}
+		if options.newIDs {
+			copied.ID = newID()
+		}
+		idMap[id] = copied.ID
+		clone.putNode(copied)
+	}
+	for _, rel := range g.relationships {
+		copied := rel
+		copied.Body = append([]byte(nil), rel.Body...)
+		if options.newIDs {
+			copied.ID = newID()
+		}
+		copied.From = idMap[rel.From]
+		copied.To = idMap[rel.To]
+		clone.putRelationship(copied)
+	}
+	return clone
+}
+
+// Snapshot returns a consistent read-only view of the graph as it is right
+// now. Only the internal maps are copied — node and relationship values are
+// shared with the live graph — so taking a snapshot is cheap and
+// long-running analyses can proceed against it while writes continue.
+func (g *Graph) Snapshot() GraphView {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	view := NewGraph()
+	view.restoreState(g.copyState())
+	return GraphView{graph: view}
+}
@@ -0,0 +1,128 @@
+package assets
+
+import "sort"
+
+// ConnectedComponents returns the groups of node IDs that are connected when
+// relationship direction is ignored, so isolated clusters of assets can be
+// found. Each group and the list of groups are sorted by ID, making the
+// result deterministic.
+func (g *Graph) ConnectedComponents() [][]string {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	options := traversalOptions{direction: Undirected}
+	seen := map[string]bool{}
+	components := [][]string{}
+	for _, id := range g.sortedVisibleNodeIDs() {
+		if seen[id] {
+			continue
+		}
+		component := []string{}
+		queue := []string{id}
+		seen[id] = true
+		for len(queue) > 0 {
+			current := queue[0]
+			queue = queue[1:]
+			component = append(component, current)
+			for _, e := range g.edgesFrom(current, options) {
+				if _, err := g.getNodeByID(e.to); err != nil {
+					continue
+				}
+				if !seen[e.to] {
+					seen[e.to] = true
+					queue = append(queue, e.to)
+				}
+			}
+		}
+		sort.Strings(component)
+		components = append(components, component)
+	}
+	return components
+}
+
+// StronglyConnectedComponents returns the groups of node IDs that can all
+// reach each other along directed relationships — the tightly coupled
+// dependency knots. It runs Kosaraju's two-pass algorithm iteratively. Each
+// group is sorted by ID and groups appear in order of their smallest member.
+func (g *Graph) StronglyConnectedComponents() [][]string {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	outgoing := traversalOptions{direction: Outgoing}
+	incoming := traversalOptions{direction: Incoming}
+	visible := map[string]bool{}
+	for _, id := range g.sortedVisibleNodeIDs() {
+		visible[id] = true
+	}
+	// First pass: record nodes in order of DFS completion.
+	finished := []string{}
+	seen := map[string]bool{}
+	for _, id := range g.sortedVisibleNodeIDs() {
+		if seen[id] {
+			continue
+		}
+		type frame struct {
+			id    string
+			edges []edge
+			index int
+		}
+		seen[id] = true
+		stack := []*frame{{id: id, edges: g.edgesFrom(id, outgoing)}}
+		for len(stack) > 0 {
+			top := stack[len(stack)-1]
+			if top.index >= len(top.edges) {
+				finished = append(finished, top.id)
+				stack = stack[:len(stack)-1]
+				continue
+			}
+			e := top.edges[top.index]
+			top.index++
+			if !visible[e.to] || seen[e.to] {
+				continue
+			}
+			seen[e.to] = true
+			stack = append(stack, &frame{id: e.to, edges: g.edgesFrom(e.to, outgoing)})
+		}
+	}
+	// Second pass: walk the reversed edges in reverse finishing order; every
+	// tree found is one strongly connected component.
+	assigned := map[string]bool{}
+	components := [][]string{}
+	for i := len(finished) - 1; i >= 0; i-- {
+		root := finished[i]
+		if assigned[root] {
+			continue
+		}
+		component := []string{}
+		queue := []string{root}
+		assigned[root] = true
+		for len(queue) > 0 {
+			current := queue[0]
+			queue = queue[1:]
+			component = append(component, current)
+			for _, e := range g.edgesFrom(current, incoming) {
+				if visible[e.to] && !assigned[e.to] {
+					assigned[e.to] = true
+					queue = append(queue, e.to)
+				}
+			}
+		}
+		sort.Strings(component)
+		components = append(components, component)
+	}
+	sort.Slice(components, func(i, j int) bool {
+		return components[i][0] < components[j][0]
+	})
+	return components
+}
+
+// sortedVisibleNodeIDs returns the IDs of visible nodes in ascending order.
+// Callers must hold at least a read lock.
+func (g *Graph) sortedVisibleNodeIDs() []string {
+	ids := make([]string, 0, len(g.nodes))
+	for id, node := range g.nodes {
+		if g.nodeVisible(node) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
@@ -0,0 +1,228 @@
+package assets
+
+import (
+	"context"
+	"time"
+)
+
+// ChainLink is one step in a traversal result. It holds the node reached at
+// this step, the relationship that was followed to reach it, and the links
+// continuing from it; an empty next slice marks the end of a path.
+type ChainLink struct {
+	node Node
+	rel  Relationship
+	next []*ChainLink
+}
+
+// Node returns the node reached at this step.
+func (c *ChainLink) Node() Node { return c.node }
+
+// Relationship returns the relationship that was followed to reach this
+// step. It is the zero Relationship on the link a traversal started from.
+func (c *ChainLink) Relationship() Relationship { return c.rel }
+
+// Next returns the links continuing from this step.
+func (c *ChainLink) Next() []*ChainLink { return c.next }
+
+// Nodes flattens the chain into the nodes it visits, in depth-first order.
+// For the linear chains returned by path searches this is simply the path.
+func (c *ChainLink) Nodes() []Node {
+	if c == nil {
+		return nil
+	}
+	nodes := []Node{c.node}
+	for _, link := range c.next {
+		nodes = append(nodes, link.Nodes()...)
+	}
+	return nodes
+}
+
+// Relationships flattens the chain into the relationships it follows, in
+// depth-first order. The zero relationship of the starting link is skipped.
+func (c *ChainLink) Relationships() []Relationship {
+	if c == nil {
+		return nil
+	}
+	relationships := []Relationship{}
+	if c.rel.ID != "" {
+		relationships = append(relationships, c.rel)
+	}
+	for _, link := range c.next {
+		relationships = append(relationships, link.Relationships()...)
+	}
+	return relationships
+}
+
+// Len returns the number of links in the chain, counting this one.
+func (c *ChainLink) Len() int {
+	if c == nil {
+		return 0
+	}
+	length := 1
+	for _, link := range c.next {
+		length += link.Len()
+	}
+	return length
+}
+
+// String renders the chain as node names joined by arrows, with branches
+// grouped in parentheses, e.g. "api -> db" or "api -> (db, cache)".
+func (c *ChainLink) String() string {
+	if c == nil {
+		return ""
+	}
+	out := c.node.Name
+	switch len(c.next) {
+	case 0:
+	case 1:
+		out += " -> " + c.next[0].String()
+	default:
+		out += " -> ("
+		for i, link := range c.next {
+			if i > 0 {
+				out += ", "
+			}
+			out += link.String()
+		}
+		out += ")"
+	}
+	return out
+}
+
+// edge pairs a relationship with the ID of the node on its far side when the
+// relationship is followed in a particular direction.
+type edge struct {
+	rel Relationship
+	to  string
+}
+
+// ListConnections walks the graph starting from the given node and returns
+// the connections found as a tree of chain links rooted at the starting node.
+// By default relationships are followed in the From->To direction; use
+// WithDirection to traverse incoming or undirected edges and WithRelFilters
+// to restrict which edges are followed. Cycles are broken by never visiting a
+// node twice on the same path.
+func (g *Graph) ListConnections(start Node, opts ...TraversalOption) (*ChainLink, error) {
+	return g.ListConnectionsCtx(context.Background(), start, opts...)
+}
+
+// ListConnectionsCtx is ListConnections with cancellation: the walk checks
+// the context between edges and aborts with its error once it is cancelled
+// or its deadline passes.
+func (g *Graph) ListConnectionsCtx(ctx context.Context, start Node, opts ...TraversalOption) (*ChainLink, error) {
+	if view := g.stableView(); view != nil {
+		began := time.Now()
+		chain, err := view.ListConnectionsCtx(ctx, start, opts...)
+		if err != nil {
+			return nil, err
+		}
+		g.recordSlowQuery("ListConnections", start.ID, began, chain.Len())
+		return chain, nil
+	}
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	if _, err := g.getNodeByID(start.ID); err != nil {
+		return nil, err
+	}
+	began := time.Now()
+	options := newTraversalOptions(opts)
+	chain, err := g.listConnections(ctx, start, options)
+	if err != nil {
+		return nil, err
+	}
+	g.recordSlowQuery("ListConnections", start.ID, began, chain.Len())
+	return chain, nil
+}
+
+// listConnections performs an iterative depth-first walk so that deep graphs
+// neither exhaust the goroutine stack nor copy visited state per edge. The
+// onPath set tracks the nodes on the path currently being extended and is
+// shared across the whole walk.
+func (g *Graph) listConnections(ctx context.Context, start Node, options traversalOptions) (*ChainLink, error) {
+	type frame struct {
+		link  *ChainLink
+		edges []edge
+		index int
+	}
+	root := &ChainLink{node: start}
+	onPath := map[string]bool{start.ID: true}
+	stack := []*frame{{link: root, edges: g.edgesFrom(start.ID, options)}}
+	for len(stack) > 0 {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		top := stack[len(stack)-1]
+		if top.index >= len(top.edges) {
+			delete(onPath, top.link.node.ID)
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		e := top.edges[top.index]
+		top.index++
+		if onPath[e.to] {
+			continue
+		}
+		next, err := g.getNodeByID(e.to)
+		if err != nil {
+			continue
+		}
+		link := &ChainLink{node: next, rel: e.rel}
+		top.link.next = append(top.link.next, link)
+		onPath[e.to] = true
+		stack = append(stack, &frame{link: link, edges: g.edgesFrom(e.to, options)})
+	}
+	return root, nil
+}
+
+// edgesFrom returns the relationships that leave the node with the given ID
+// when followed in the configured direction, paired with the ID of the node
+// on the far side. It reads the adjacency indexes, so its cost is
+// proportional to the degree of the node rather than the size of the graph.
+// Callers must hold at least a read lock.
+func (g *Graph) edgesFrom(nodeID string, options traversalOptions) []edge {
+	edges := []edge{}
+	cutoff := now()
+	allowed := func(toID string) bool {
+		if options.throughLabels == nil && options.avoidLabels == nil {
+			return true
+		}
+		label := g.nodes[toID].Label
+		if options.avoidLabels[label] {
+			return false
+		}
+		return options.throughLabels == nil || options.throughLabels[label]
+	}
+	// Undirected relationships are followed both ways whatever the
+	// traversal direction; only their directed counterparts are gated on it.
+	if options.direction == Outgoing || options.direction == Undirected {
+		for relID := range g.relsByFrom[nodeID] {
+			rel := g.relationships[relID]
+			if !rel.expiredAt(cutoff) && matchesRelationship(rel, options.relFilters) && allowed(rel.To) {
+				edges = append(edges, edge{rel: rel, to: rel.To})
+			}
+		}
+	} else {
+		for relID := range g.relsByFrom[nodeID] {
+			rel := g.relationships[relID]
+			if rel.Undirected && !rel.expiredAt(cutoff) && matchesRelationship(rel, options.relFilters) && allowed(rel.To) {
+				edges = append(edges, edge{rel: rel, to: rel.To})
+			}
+		}
+	}
+	if options.direction == Incoming || options.direction == Undirected {
+		for relID := range g.relsByTo[nodeID] {
+			rel := g.relationships[relID]
+			if !rel.expiredAt(cutoff) && matchesRelationship(rel, options.relFilters) && allowed(rel.From) {
+				edges = append(edges, edge{rel: rel, to: rel.From})
+			}
+		}
+	} else {
+		for relID := range g.relsByTo[nodeID] {
+			rel := g.relationships[relID]
+			if rel.Undirected && !rel.expiredAt(cutoff) && matchesRelationship(rel, options.relFilters) && allowed(rel.From) {
+				edges = append(edges, edge{rel: rel, to: rel.From})
+			}
+		}
+	}
+	return edges
+}
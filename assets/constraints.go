@@ -0,0 +1,50 @@
+package assets
+
+import "fmt"
+
+// WithUniqueNames makes the graph enforce that at most one live node exists
+// for each name and label pair. Inserting a duplicate fails with an error
+// wrapping ErrAlreadyExists.
+func WithUniqueNames() GraphOption {
+	return func(g *Graph) {
+		g.uniqueNames = true
+	}
+}
+
+// relTriple identifies one legal edge shape in the relationship model.
+type relTriple struct {
+	fromLabel string
+	relLabel  string
+	toLabel   string
+}
+
+// AllowRelationship declares that relationships with the given label may run
+// from nodes of fromLabel to nodes of toLabel, e.g.
+//
+//	g.AllowRelationship("service", "runs_on", "host")
+//
+// Declaring the first triple switches the graph into enforcing mode: from
+// then on AddRelationship rejects any edge whose (from label, relationship
+// label, to label) shape has not been declared, wrapping
+// ErrConstraintViolation. A graph with no declared triples accepts
+// everything, as before.
+func (g *Graph) AllowRelationship(fromLabel, relLabel, toLabel string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.relModel == nil {
+		g.relModel = map[relTriple]bool{}
+	}
+	g.relModel[relTriple{fromLabel: fromLabel, relLabel: relLabel, toLabel: toLabel}] = true
+}
+
+// checkRelationshipAllowed enforces the declared relationship model, if any.
+// Callers must hold at least a read lock.
+func (g *Graph) checkRelationshipAllowed(from, to Node, relLabel string) error {
+	if len(g.relModel) == 0 {
+		return nil
+	}
+	if g.relModel[relTriple{fromLabel: from.Label, relLabel: relLabel, toLabel: to.Label}] {
+		return nil
+	}
+	return fmt.Errorf("%w: relationship %s -%s-> %s is not in the declared model", ErrConstraintViolation, from.Label, relLabel, to.Label)
+}
@@ -0,0 +1,122 @@
+package assets
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CrossRef qualifies a node with the namespace its graph is registered
+// under, written "namespace/nodeID".
+type CrossRef struct {
+	Namespace string `json:"namespace"`
+	NodeID    string `json:"node_id"`
+}
+
+// String renders the reference in its "namespace/nodeID" form.
+func (c CrossRef) String() string {
+	return c.Namespace + "/" + c.NodeID
+}
+
+// ParseCrossRef parses a "namespace/nodeID" reference.
+func ParseCrossRef(s string) (CrossRef, error) {
+	namespace, nodeID, ok := strings.Cut(s, "/")
+	if !ok || namespace == "" || nodeID == "" {
+		return CrossRef{}, fmt.Errorf("invalid cross-graph reference %q: want namespace/nodeID", s)
+	}
+	return CrossRef{Namespace: namespace, NodeID: nodeID}, nil
+}
+
+// CrossRelationship is an edge whose endpoints live in different graphs of a
+// registry, e.g. a prod service depending on a shared-infra load balancer.
+type CrossRelationship struct {
+	ID    string   `json:"id"`
+	From  CrossRef `json:"from"`
+	To    CrossRef `json:"to"`
+	Label string   `json:"label"`
+}
+
+// AddCrossRelationship links two nodes across namespaces. Both endpoints
+// must exist in their respective graphs; ErrNotFound is returned otherwise.
+func (r *Registry) AddCrossRelationship(from, to CrossRef, label string) (CrossRelationship, error) {
+	if _, err := r.Graph(from.Namespace).GetNodeByID(from.NodeID); err != nil {
+		return CrossRelationship{}, fmt.Errorf("from node %s: %w", from, err)
+	}
+	if _, err := r.Graph(to.Namespace).GetNodeByID(to.NodeID); err != nil {
+		return CrossRelationship{}, fmt.Errorf("to node %s: %w", to, err)
+	}
+	rel := CrossRelationship{ID: newID(), From: from, To: to, Label: label}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.crossRels = append(r.crossRels, rel)
+	return rel, nil
+}
+
+// DeleteCrossRelationship removes the cross-graph relationship with the
+// given ID.
+func (r *Registry) DeleteCrossRelationship(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, rel := range r.crossRels {
+		if rel.ID == id {
+			r.crossRels = append(r.crossRels[:i], r.crossRels[i+1:]...)
+			return nil
+		}
+	}
+	return ErrNotFound
+}
+
+// CrossRelationships returns every cross-graph relationship in the registry.
+func (r *Registry) CrossRelationships() []CrossRelationship {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return append([]CrossRelationship{}, r.crossRels...)
+}
+
+// CrossRelationshipsOf returns the cross-graph relationships touching the
+// given node, whether as From or as To.
+func (r *Registry) CrossRelationshipsOf(ref CrossRef) []CrossRelationship {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	touching := []CrossRelationship{}
+	for _, rel := range r.crossRels {
+		if rel.From == ref || rel.To == ref {
+			touching = append(touching, rel)
+		}
+	}
+	return touching
+}
+
+// NeighborsAcross returns the neighbours of the given node including those
+// reached over cross-graph relationships, each qualified with its namespace.
+// Traversal options apply to the in-graph neighbours; cross-graph edges are
+// followed in the From->To direction unless the options say otherwise.
+func (r *Registry) NeighborsAcross(ref CrossRef, opts ...TraversalOption) []CrossRef {
+	options := newTraversalOptions(opts)
+	neighbours := []CrossRef{}
+	seen := map[CrossRef]bool{}
+	for _, node := range r.Graph(ref.Namespace).Neighbors(ref.NodeID, opts...) {
+		local := CrossRef{Namespace: ref.Namespace, NodeID: node.ID}
+		if !seen[local] {
+			seen[local] = true
+			neighbours = append(neighbours, local)
+		}
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, rel := range r.crossRels {
+		var far CrossRef
+		switch {
+		case rel.From == ref && (options.direction == Outgoing || options.direction == Undirected):
+			far = rel.To
+		case rel.To == ref && (options.direction == Incoming || options.direction == Undirected):
+			far = rel.From
+		default:
+			continue
+		}
+		if !seen[far] {
+			seen[far] = true
+			neighbours = append(neighbours, far)
+		}
+	}
+	return neighbours
+}
@@ -0,0 +1,128 @@
+package assets
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+)
+
+var (
+	csvNodeHeader = []string{"name", "label", "body"}
+	csvRelHeader  = []string{"from", "to", "label"}
+)
+
+// ImportCSV builds a graph from two CSV documents: one listing nodes with the
+// columns "name,label,body" and one listing relationships with the columns
+// "from,to,label", where from and to hold node names. Each relationship
+// endpoint must resolve to exactly one imported node. A header row matching
+// the expected columns is skipped when present.
+func ImportCSV(nodesReader, relsReader io.Reader) (*Graph, error) {
+	graph := NewGraph()
+	nodeRows, err := readCSVRows(nodesReader, csvNodeHeader)
+	if err != nil {
+		return nil, fmt.Errorf("reading nodes: %w", err)
+	}
+	for _, row := range nodeRows {
+		var body []byte
+		if row[2] != "" {
+			body = []byte(row[2])
+		}
+		if _, err := graph.AddNode(row[0], row[1], body); err != nil {
+			return nil, fmt.Errorf("adding node %q: %w", row[0], err)
+		}
+	}
+	relRows, err := readCSVRows(relsReader, csvRelHeader)
+	if err != nil {
+		return nil, fmt.Errorf("reading relationships: %w", err)
+	}
+	for _, row := range relRows {
+		from, err := graph.singleNodeByName(row[0])
+		if err != nil {
+			return nil, fmt.Errorf("resolving relationship endpoint %q: %w", row[0], err)
+		}
+		to, err := graph.singleNodeByName(row[1])
+		if err != nil {
+			return nil, fmt.Errorf("resolving relationship endpoint %q: %w", row[1], err)
+		}
+		if _, err := graph.AddRelationship(from, to, row[2]); err != nil {
+			return nil, err
+		}
+	}
+	return graph, nil
+}
+
+// ExportCSV writes the graph as two CSV documents using the same column
+// layout accepted by ImportCSV. Relationship endpoints are written as node
+// names, so graphs whose names are not unique cannot be round-tripped
+// faithfully through CSV.
+func (g *Graph) ExportCSV(nodesWriter, relsWriter io.Writer) error {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	nodes := csv.NewWriter(nodesWriter)
+	if err := nodes.Write(csvNodeHeader); err != nil {
+		return err
+	}
+	for _, node := range sortedNodes(g.nodes) {
+		if err := nodes.Write([]string{node.Name, node.Label, string(node.Body)}); err != nil {
+			return err
+		}
+	}
+	nodes.Flush()
+	if err := nodes.Error(); err != nil {
+		return err
+	}
+	rels := csv.NewWriter(relsWriter)
+	if err := rels.Write(csvRelHeader); err != nil {
+		return err
+	}
+	for _, rel := range sortedRelationships(g.relationships) {
+		from, okFrom := g.nodes[rel.From]
+		to, okTo := g.nodes[rel.To]
+		if !okFrom || !okTo {
+			continue
+		}
+		if err := rels.Write([]string{from.Name, to.Name, rel.Label}); err != nil {
+			return err
+		}
+	}
+	rels.Flush()
+	return rels.Error()
+}
+
+// readCSVRows reads every record with the expected column count, skipping a
+// leading header row when it matches the expected one.
+func readCSVRows(r io.Reader, header []string) ([][]string, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = len(header)
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) > 0 && equalRow(rows[0], header) {
+		rows = rows[1:]
+	}
+	return rows, nil
+}
+
+func equalRow(row, header []string) bool {
+	for i := range header {
+		if row[i] != header[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// singleNodeByName returns the only node with the given name, or an error
+// when the name is missing or ambiguous.
+func (g *Graph) singleNodeByName(name string) (Node, error) {
+	nodes := g.GetNodesByName(name)
+	switch len(nodes) {
+	case 0:
+		return Node{}, ErrNotFound
+	case 1:
+		return nodes[0], nil
+	default:
+		return Node{}, fmt.Errorf("name %q matches %d nodes", name, len(nodes))
+	}
+}
@@ -0,0 +1,55 @@
+package assets
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ExportCypher writes the graph as a sequence of Cypher statements that
+// recreate it when run against an empty Neo4j database. Node labels and
+// relationship labels become Cypher labels and relationship types; IDs,
+// names and bodies are stored as properties so relationships can be matched
+// back to their endpoints.
+func (g *Graph) ExportCypher(w io.Writer) error {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	for _, node := range sortedNodes(g.nodes) {
+		properties := []string{
+			fmt.Sprintf("id: %s", cypherString(node.ID)),
+			fmt.Sprintf("name: %s", cypherString(node.Name)),
+		}
+		if len(node.Body) > 0 {
+			properties = append(properties, fmt.Sprintf("body: %s", cypherString(string(node.Body))))
+		}
+		statement := fmt.Sprintf("CREATE (:%s {%s});\n", cypherIdentifier(node.Label), strings.Join(properties, ", "))
+		if _, err := io.WriteString(w, statement); err != nil {
+			return err
+		}
+	}
+	for _, rel := range sortedRelationships(g.relationships) {
+		statement := fmt.Sprintf(
+			"MATCH (a {id: %s}), (b {id: %s}) CREATE (a)-[:%s {id: %s}]->(b);\n",
+			cypherString(rel.From), cypherString(rel.To), cypherIdentifier(rel.Label), cypherString(rel.ID),
+		)
+		if _, err := io.WriteString(w, statement); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// cypherString quotes a value as a Cypher string literal.
+func cypherString(s string) string {
+	return "\"" + strings.NewReplacer("\\", "\\\\", "\"", "\\\"", "\n", "\\n").Replace(s) + "\""
+}
+
+// cypherIdentifier quotes a label for use as a Cypher label or relationship
+// type, since asset labels may contain characters Cypher identifiers do not
+// allow.
+func cypherIdentifier(s string) string {
+	if s == "" {
+		s = "asset"
+	}
+	return "`" + strings.ReplaceAll(s, "`", "``") + "`"
+}
@@ -0,0 +1,146 @@
+package assets
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// DedupOption configures FindDuplicates.
+type DedupOption func(*dedupOptions)
+
+type dedupOptions struct {
+	labels      map[string]bool
+	normalized  bool
+	maxDistance int
+}
+
+// DedupWithinLabels restricts duplicate detection to nodes carrying one of
+// the given labels. By default every label is scanned.
+func DedupWithinLabels(labels ...string) DedupOption {
+	return func(o *dedupOptions) {
+		if o.labels == nil {
+			o.labels = map[string]bool{}
+		}
+		for _, label := range labels {
+			o.labels[label] = true
+		}
+	}
+}
+
+// DedupNormalized compares names case-insensitively with punctuation and
+// whitespace stripped, so "web-01.prod" and "Web 01 Prod" group together.
+func DedupNormalized() DedupOption {
+	return func(o *dedupOptions) {
+		o.normalized = true
+	}
+}
+
+// DedupMaxDistance additionally groups names within the given Levenshtein
+// distance of each other, catching typos and off-by-one suffixes. Distances
+// are measured on the normalized form when DedupNormalized is also set.
+func DedupMaxDistance(distance int) DedupOption {
+	return func(o *dedupOptions) {
+		o.maxDistance = distance
+	}
+}
+
+// FindDuplicates groups nodes that appear to describe the same asset: nodes
+// sharing a label whose names are identical, identical after normalization,
+// or within an edit-distance threshold, depending on the options. Each group
+// has at least two nodes and is ordered by node ID; feed pairs from a group
+// to MergeNodes to resolve them. Messy inventories built from several
+// scanners are the intended target.
+func (g *Graph) FindDuplicates(opts ...DedupOption) [][]Node {
+	options := dedupOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	g.mu.RLock()
+	byLabel := map[string][]Node{}
+	for _, node := range g.nodes {
+		if !g.nodeVisible(node) {
+			continue
+		}
+		if options.labels != nil && !options.labels[node.Label] {
+			continue
+		}
+		byLabel[node.Label] = append(byLabel[node.Label], node)
+	}
+	g.mu.RUnlock()
+	groups := [][]Node{}
+	for _, nodes := range byLabel {
+		groups = append(groups, duplicateGroups(nodes, options)...)
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i][0].ID < groups[j][0].ID })
+	return groups
+}
+
+// duplicateGroups clusters one label's nodes by comparison key, unioning
+// clusters whose keys are within the edit-distance threshold.
+func duplicateGroups(nodes []Node, options dedupOptions) [][]Node {
+	keys := make([]string, len(nodes))
+	for i, node := range nodes {
+		keys[i] = node.Name
+		if options.normalized {
+			keys[i] = normalizeName(node.Name)
+		}
+	}
+	// Union-find over node indexes; exact key matches and near matches end
+	// up in the same set.
+	parent := make([]int, len(nodes))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+	union := func(i, j int) { parent[find(i)] = find(j) }
+	byKey := map[string]int{}
+	for i, key := range keys {
+		if first, ok := byKey[key]; ok {
+			union(i, first)
+		} else {
+			byKey[key] = i
+		}
+	}
+	if options.maxDistance > 0 {
+		for i := 0; i < len(nodes); i++ {
+			for j := i + 1; j < len(nodes); j++ {
+				if find(i) != find(j) && editDistance(keys[i], keys[j]) <= options.maxDistance {
+					union(i, j)
+				}
+			}
+		}
+	}
+	clusters := map[int][]Node{}
+	for i, node := range nodes {
+		root := find(i)
+		clusters[root] = append(clusters[root], node)
+	}
+	groups := [][]Node{}
+	for _, cluster := range clusters {
+		if len(cluster) < 2 {
+			continue
+		}
+		sort.Slice(cluster, func(i, j int) bool { return cluster[i].ID < cluster[j].ID })
+		groups = append(groups, cluster)
+	}
+	return groups
+}
+
+// normalizeName lowercases a name and drops everything that is not a letter
+// or digit.
+func normalizeName(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
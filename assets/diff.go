@@ -0,0 +1,139 @@
+package assets
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// NodeChange pairs the two states of a node that differs between the diffed
+// graphs.
+type NodeChange struct {
+	Before Node `json:"before"`
+	After  Node `json:"after"`
+}
+
+// RelChange pairs the two states of a relationship that differs between the
+// diffed graphs.
+type RelChange struct {
+	Before Relationship `json:"before"`
+	After  Relationship `json:"after"`
+}
+
+// GraphDiff lists the differences between two graphs. Added means present
+// only in the second graph, removed means present only in the first, and
+// changed means present in both but with different contents.
+type GraphDiff struct {
+	NodesAdded           []Node         `json:"nodes_added,omitempty"`
+	NodesRemoved         []Node         `json:"nodes_removed,omitempty"`
+	NodesChanged         []NodeChange   `json:"nodes_changed,omitempty"`
+	RelationshipsAdded   []Relationship `json:"relationships_added,omitempty"`
+	RelationshipsRemoved []Relationship `json:"relationships_removed,omitempty"`
+	RelationshipsChanged []RelChange    `json:"relationships_changed,omitempty"`
+}
+
+// Diff compares two graphs, matching nodes and relationships by ID, and
+// returns what changed going from a to b. The result marshals to JSON for
+// machine consumption and renders a line-per-change summary via String.
+func Diff(a, b *Graph) GraphDiff {
+	diff := GraphDiff{}
+	aNodes := a.ListNodes()
+	bNodes := b.ListNodes()
+	bByID := make(map[string]Node, len(bNodes))
+	for _, node := range bNodes {
+		bByID[node.ID] = node
+	}
+	for _, node := range sortedNodeSlice(aNodes) {
+		other, ok := bByID[node.ID]
+		if !ok {
+			diff.NodesRemoved = append(diff.NodesRemoved, node)
+			continue
+		}
+		if !sameNodeContent(node, other) {
+			diff.NodesChanged = append(diff.NodesChanged, NodeChange{Before: node, After: other})
+		}
+		delete(bByID, node.ID)
+	}
+	for _, node := range sortedNodeSlice(bNodes) {
+		if _, stillThere := bByID[node.ID]; stillThere {
+			diff.NodesAdded = append(diff.NodesAdded, node)
+		}
+	}
+	aRels := a.ListRelationships()
+	bRels := b.ListRelationships()
+	bRelByID := make(map[string]Relationship, len(bRels))
+	for _, rel := range bRels {
+		bRelByID[rel.ID] = rel
+	}
+	for _, rel := range sortedRelSlice(aRels) {
+		other, ok := bRelByID[rel.ID]
+		if !ok {
+			diff.RelationshipsRemoved = append(diff.RelationshipsRemoved, rel)
+			continue
+		}
+		if !sameRelContent(rel, other) {
+			diff.RelationshipsChanged = append(diff.RelationshipsChanged, RelChange{Before: rel, After: other})
+		}
+		delete(bRelByID, rel.ID)
+	}
+	for _, rel := range sortedRelSlice(bRels) {
+		if _, stillThere := bRelByID[rel.ID]; stillThere {
+			diff.RelationshipsAdded = append(diff.RelationshipsAdded, rel)
+		}
+	}
+	return diff
+}
+
+// Empty reports whether the two graphs were identical.
+func (d GraphDiff) Empty() bool {
+	return len(d.NodesAdded) == 0 && len(d.NodesRemoved) == 0 && len(d.NodesChanged) == 0 &&
+		len(d.RelationshipsAdded) == 0 && len(d.RelationshipsRemoved) == 0 && len(d.RelationshipsChanged) == 0
+}
+
+// String renders the diff with one line per change, prefixed with +, - or ~.
+func (d GraphDiff) String() string {
+	var b strings.Builder
+	for _, node := range d.NodesAdded {
+		fmt.Fprintf(&b, "+ node %s (%s)\n", node.Name, node.Label)
+	}
+	for _, node := range d.NodesRemoved {
+		fmt.Fprintf(&b, "- node %s (%s)\n", node.Name, node.Label)
+	}
+	for _, change := range d.NodesChanged {
+		fmt.Fprintf(&b, "~ node %s (%s)\n", change.After.Name, change.After.Label)
+	}
+	for _, rel := range d.RelationshipsAdded {
+		fmt.Fprintf(&b, "+ rel %s -%s-> %s\n", rel.From, rel.Label, rel.To)
+	}
+	for _, rel := range d.RelationshipsRemoved {
+		fmt.Fprintf(&b, "- rel %s -%s-> %s\n", rel.From, rel.Label, rel.To)
+	}
+	for _, change := range d.RelationshipsChanged {
+		fmt.Fprintf(&b, "~ rel %s -%s-> %s\n", change.After.From, change.After.Label, change.After.To)
+	}
+	return b.String()
+}
+
+func sameNodeContent(a, b Node) bool {
+	return a.Name == b.Name && a.Label == b.Label && bytes.Equal(a.Body, b.Body)
+}
+
+func sameRelContent(a, b Relationship) bool {
+	return a.From == b.From && a.To == b.To && a.Label == b.Label && bytes.Equal(a.Body, b.Body)
+}
+
+func sortedNodeSlice(nodes []Node) []Node {
+	byID := make(map[string]Node, len(nodes))
+	for _, node := range nodes {
+		byID[node.ID] = node
+	}
+	return sortedNodes(byID)
+}
+
+func sortedRelSlice(rels []Relationship) []Relationship {
+	byID := make(map[string]Relationship, len(rels))
+	for _, rel := range rels {
+		byID[rel.ID] = rel
+	}
+	return sortedRelationships(byID)
+}
@@ -0,0 +1,221 @@
+// Package discovery turns an asset graph into a continuously updated
+// inventory. Discovery sources — scanners, cloud APIs, CMDB exports —
+// implement the Source interface, and a Scheduler runs them on intervals,
+// upserting what they report and removing assets a source stops reporting.
+package discovery
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/mimatache/asset-graph/assets"
+)
+
+// NodeSpec is one asset as reported by a source. Nodes are identified by
+// name and label, so repeated discoveries update rather than duplicate.
+type NodeSpec struct {
+	Name  string
+	Label string
+	Body  []byte
+}
+
+// RelSpec is one relationship as reported by a source, with its endpoints
+// named the same way as NodeSpecs.
+type RelSpec struct {
+	FromName  string
+	FromLabel string
+	ToName    string
+	ToLabel   string
+	Label     string
+	Body      []byte
+}
+
+// Source discovers assets from one system of record. Discover is called on
+// the schedule the source was registered with and returns the complete
+// current view of the source; assets it stops reporting are removed from the
+// graph.
+type Source interface {
+	Name() string
+	Discover(ctx context.Context) ([]NodeSpec, []RelSpec, error)
+}
+
+// SchedulerOption configures a scheduler.
+type SchedulerOption func(*Scheduler)
+
+// WithErrorHandler sets the function invoked when a source fails to
+// discover. Without a handler failures are silently skipped until the next
+// interval.
+func WithErrorHandler(fn func(source string, err error)) SchedulerOption {
+	return func(s *Scheduler) {
+		s.onError = fn
+	}
+}
+
+// Scheduler runs registered sources on their intervals and reconciles the
+// graph with what they report.
+type Scheduler struct {
+	graph   *assets.Graph
+	onError func(source string, err error)
+
+	mu       sync.Mutex
+	sources  []scheduledSource
+	reported map[string]map[string]bool // source name -> node IDs it last reported
+	stop     chan struct{}
+	wg       sync.WaitGroup
+}
+
+// scheduledSource pairs a source with how often it runs.
+type scheduledSource struct {
+	source   Source
+	interval time.Duration
+}
+
+// NewScheduler returns a scheduler reconciling into the given graph.
+func NewScheduler(g *assets.Graph, opts ...SchedulerOption) *Scheduler {
+	scheduler := &Scheduler{
+		graph:    g,
+		reported: map[string]map[string]bool{},
+	}
+	for _, opt := range opts {
+		opt(scheduler)
+	}
+	return scheduler
+}
+
+// Register adds a source to be run every interval once the scheduler is
+// started.
+func (s *Scheduler) Register(source Source, interval time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sources = append(s.sources, scheduledSource{source: source, interval: interval})
+}
+
+// Start runs every registered source immediately and then on its interval,
+// until Stop is called.
+func (s *Scheduler) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stop != nil {
+		return
+	}
+	s.stop = make(chan struct{})
+	for _, scheduled := range s.sources {
+		scheduled := scheduled
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.runScheduled(scheduled)
+		}()
+	}
+}
+
+// Stop halts the scheduler and waits for in-flight discoveries to finish.
+func (s *Scheduler) Stop() {
+	s.mu.Lock()
+	stop := s.stop
+	s.stop = nil
+	s.mu.Unlock()
+	if stop == nil {
+		return
+	}
+	close(stop)
+	s.wg.Wait()
+}
+
+func (s *Scheduler) runScheduled(scheduled scheduledSource) {
+	s.mu.Lock()
+	stop := s.stop
+	s.mu.Unlock()
+	run := func() {
+		if err := s.RunOnce(context.Background(), scheduled.source); err != nil && s.onError != nil {
+			s.onError(scheduled.source.Name(), err)
+		}
+	}
+	run()
+	ticker := time.NewTicker(scheduled.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			run()
+		}
+	}
+}
+
+// RunOnce performs a single discovery of the given source and reconciles the
+// graph: reported nodes are inserted or updated, reported relationships are
+// added if missing, and nodes this source reported last time but not this
+// time are deleted.
+func (s *Scheduler) RunOnce(ctx context.Context, source Source) error {
+	nodeSpecs, relSpecs, err := source.Discover(ctx)
+	if err != nil {
+		return fmt.Errorf("discovering %s: %w", source.Name(), err)
+	}
+	current := map[string]bool{}
+	for _, spec := range nodeSpecs {
+		node, err := s.upsertNode(source.Name(), spec)
+		if err != nil {
+			return err
+		}
+		current[node.ID] = true
+	}
+	for _, spec := range relSpecs {
+		if err := s.upsertRelationship(source.Name(), spec); err != nil {
+			return err
+		}
+	}
+	s.mu.Lock()
+	previous := s.reported[source.Name()]
+	s.reported[source.Name()] = current
+	s.mu.Unlock()
+	for id := range previous {
+		if !current[id] {
+			if err := s.graph.DeleteNode(id); err != nil && err != assets.ErrNotFound {
+				return fmt.Errorf("expiring node %s: %w", id, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Scheduler) upsertNode(sourceName string, spec NodeSpec) (assets.Node, error) {
+	existing, err := s.graph.GetNodeByNameAndLabel(spec.Name, spec.Label)
+	if err == nil {
+		updated, err := s.graph.UpdateNode(existing.ID, spec.Body)
+		if err != nil {
+			return assets.Node{}, fmt.Errorf("updating %s %q: %w", spec.Label, spec.Name, err)
+		}
+		return updated, nil
+	}
+	node, err := s.graph.AddNode(spec.Name, spec.Label, spec.Body, assets.AsActor(sourceName))
+	if err != nil {
+		return assets.Node{}, fmt.Errorf("inserting %s %q: %w", spec.Label, spec.Name, err)
+	}
+	return node, nil
+}
+
+func (s *Scheduler) upsertRelationship(sourceName string, spec RelSpec) error {
+	from, err := s.graph.GetNodeByNameAndLabel(spec.FromName, spec.FromLabel)
+	if err != nil {
+		return fmt.Errorf("relationship endpoint %s %q: %w", spec.FromLabel, spec.FromName, err)
+	}
+	to, err := s.graph.GetNodeByNameAndLabel(spec.ToName, spec.ToLabel)
+	if err != nil {
+		return fmt.Errorf("relationship endpoint %s %q: %w", spec.ToLabel, spec.ToName, err)
+	}
+	if s.graph.HasRelationship(from.ID, to.ID, spec.Label) {
+		return nil
+	}
+	opts := []assets.RelOption{assets.AsRelActor(sourceName)}
+	if spec.Body != nil {
+		opts = append(opts, assets.WithRelBody(spec.Body))
+	}
+	if _, err := s.graph.AddRelationship(from, to, spec.Label, opts...); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package assets
+
+import "errors"
+
+// The package reports failures by wrapping one of the sentinel errors below,
+// so callers branch on failure modes with errors.Is rather than by matching
+// message strings.
+
+// ErrNotFound is returned when the requested node or relationship does not
+// exist in the graph.
+var ErrNotFound = errors.New("not found")
+
+// ErrAlreadyExists is returned when inserting a node that a uniqueness
+// constraint says is already present.
+var ErrAlreadyExists = errors.New("already exists")
+
+// ErrHasRelationships is returned by DeleteNode when DeleteRestrict is set
+// and relationships still reference the node being deleted.
+var ErrHasRelationships = errors.New("node still has relationships")
+
+// ErrNoPath is returned by path searches when no connection exists between
+// the requested nodes.
+var ErrNoPath = errors.New("no path between nodes")
+
+// ErrVersionMismatch is returned by UpdateNodeIfVersion when the node has
+// been updated since the caller read it.
+var ErrVersionMismatch = errors.New("node version mismatch")
+
+// ErrBodyMismatch is returned by UpdateNodeIf when the node's current body
+// is not the one the caller expected.
+var ErrBodyMismatch = errors.New("node body mismatch")
+
+// ErrValidation wraps failures of a node body against the JSON Schema
+// registered for its label.
+var ErrValidation = errors.New("body validation failed")
+
+// ErrConstraintViolation wraps mutations rejected by a declared graph
+// constraint, such as the relationship model.
+var ErrConstraintViolation = errors.New("constraint violation")
+
+// ErrCycle is returned by orderings and traversals that require an acyclic
+// graph when a cycle is encountered.
+var ErrCycle = errors.New("graph contains a cycle")
@@ -0,0 +1,190 @@
+package assets
+
+import (
+	"path"
+	"regexp"
+	"time"
+)
+
+// Filter narrows down the nodes returned by list operations. A node is kept
+// only when every filter returns true for it.
+type Filter func(Node) bool
+
+// FilterNodesByLabel keeps nodes whose label matches the given value.
+func FilterNodesByLabel(label string) Filter {
+	return func(n Node) bool {
+		return n.Label == label
+	}
+}
+
+// FilterNodesByName keeps nodes whose name matches the given value.
+func FilterNodesByName(name string) Filter {
+	return func(n Node) bool {
+		return n.Name == name
+	}
+}
+
+// FilterNodesByNameRegex keeps nodes whose name matches the given regular
+// expression. An invalid pattern yields a filter that matches nothing.
+func FilterNodesByNameRegex(pattern string) Filter {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return func(Node) bool { return false }
+	}
+	return func(n Node) bool {
+		return re.MatchString(n.Name)
+	}
+}
+
+// FilterNodesByLabelGlob keeps nodes whose label matches the given glob
+// pattern, e.g. "aws-*". An invalid pattern yields a filter that matches
+// nothing.
+func FilterNodesByLabelGlob(pattern string) Filter {
+	return func(n Node) bool {
+		matched, err := path.Match(pattern, n.Label)
+		return err == nil && matched
+	}
+}
+
+// FilterNodesUpdatedSince keeps nodes updated at or after the given time.
+func FilterNodesUpdatedSince(t time.Time) Filter {
+	return func(n Node) bool {
+		return !n.UpdatedAt.Before(t)
+	}
+}
+
+// FilterNodesCreatedSince keeps nodes created at or after the given time.
+func FilterNodesCreatedSince(t time.Time) Filter {
+	return func(n Node) bool {
+		return !n.CreatedAt.Before(t)
+	}
+}
+
+// FilterRelationship narrows down the relationships returned by list
+// operations. A relationship is kept only when every filter returns true.
+type FilterRelationship func(Relationship) bool
+
+// FilterRelByLabel keeps relationships whose label matches the given value.
+func FilterRelByLabel(label string) FilterRelationship {
+	return func(r Relationship) bool {
+		return r.Label == label
+	}
+}
+
+// FilterRelByFrom keeps relationships starting at the node with the given ID.
+func FilterRelByFrom(id string) FilterRelationship {
+	return func(r Relationship) bool {
+		return r.From == id
+	}
+}
+
+// FilterRelByTo keeps relationships ending at the node with the given ID.
+func FilterRelByTo(id string) FilterRelationship {
+	return func(r Relationship) bool {
+		return r.To == id
+	}
+}
+
+// FilterRelByNode keeps relationships touching the node with the given ID,
+// whether as From or as To.
+func FilterRelByNode(id string) FilterRelationship {
+	return func(r Relationship) bool {
+		return r.From == id || r.To == id
+	}
+}
+
+// FilterRelByWeightAbove keeps relationships whose weight is strictly
+// greater than the given threshold. Unweighted relationships count as
+// weight 1, matching StoredWeight.
+func FilterRelByWeightAbove(threshold float64) FilterRelationship {
+	return func(r Relationship) bool {
+		return StoredWeight(r) > threshold
+	}
+}
+
+// FilterRelsActiveAt keeps relationships whose validity window contained the
+// given time. Relationships without validity bounds always match.
+func FilterRelsActiveAt(t time.Time) FilterRelationship {
+	return func(r Relationship) bool {
+		return r.activeAt(t)
+	}
+}
+
+// FilterRelsUpdatedSince keeps relationships updated at or after the given
+// time.
+func FilterRelsUpdatedSince(t time.Time) FilterRelationship {
+	return func(r Relationship) bool {
+		return !r.UpdatedAt.Before(t)
+	}
+}
+
+// And keeps nodes matching every given filter. List operations already AND
+// their filters; And exists so a conjunction can be nested inside Or or Not.
+func And(filters ...Filter) Filter {
+	return func(n Node) bool {
+		return matchesNode(n, filters)
+	}
+}
+
+// Or keeps nodes matching at least one of the given filters.
+func Or(filters ...Filter) Filter {
+	return func(n Node) bool {
+		for _, f := range filters {
+			if f(n) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Not keeps nodes the given filter rejects.
+func Not(filter Filter) Filter {
+	return func(n Node) bool {
+		return !filter(n)
+	}
+}
+
+// AndRel keeps relationships matching every given filter.
+func AndRel(filters ...FilterRelationship) FilterRelationship {
+	return func(r Relationship) bool {
+		return matchesRelationship(r, filters)
+	}
+}
+
+// OrRel keeps relationships matching at least one of the given filters.
+func OrRel(filters ...FilterRelationship) FilterRelationship {
+	return func(r Relationship) bool {
+		for _, f := range filters {
+			if f(r) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// NotRel keeps relationships the given filter rejects.
+func NotRel(filter FilterRelationship) FilterRelationship {
+	return func(r Relationship) bool {
+		return !filter(r)
+	}
+}
+
+func matchesNode(n Node, filters []Filter) bool {
+	for _, f := range filters {
+		if !f(n) {
+			return false
+		}
+	}
+	return true
+}
+
+func matchesRelationship(r Relationship, filters []FilterRelationship) bool {
+	for _, f := range filters {
+		if !f(r) {
+			return false
+		}
+	}
+	return true
+}
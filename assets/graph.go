@@ -0,0 +1,761 @@
+package assets
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// now is stubbed in tests that need deterministic timestamps.
+var now = time.Now
+
+// Graph is an in-memory collection of nodes and the relationships between
+// them. All operations are safe for concurrent use.
+type Graph struct {
+	mu            sync.RWMutex
+	nodes         map[string]Node
+	relationships map[string]Relationship
+	// relsByFrom and relsByTo index relationship IDs by their endpoints so
+	// traversals cost O(degree) instead of scanning every relationship.
+	relsByFrom map[string]map[string]bool
+	relsByTo   map[string]map[string]bool
+	// nodesByLabel indexes node IDs by label so listing the nodes of one
+	// label costs O(matches) instead of scanning every node.
+	nodesByLabel map[string]map[string]bool
+	// nodesByName indexes node IDs by name; names are the natural external
+	// key for assets, so lookups by name should not scan the whole graph.
+	nodesByName map[string]map[string]bool
+	// wal, when set, receives every mutation before it is acknowledged so
+	// the graph can be rebuilt after a crash. See OpenGraph.
+	wal *wal
+	// hooks run around every mutation; see the On* registration methods.
+	hooks hooks
+	// pendingWAL and deferredAfter buffer log entries and after-hooks while
+	// a transaction is open so they only take effect on commit. See Tx.
+	pendingWAL    *[]walEntry
+	deferredAfter *[]func()
+	// history holds previous node revisions when enabled via WithHistory.
+	historyEnabled bool
+	historyLimit   int
+	history        map[string][]NodeRevision
+	// softDelete makes DeleteNode tombstone nodes instead of removing them.
+	softDelete bool
+	// schemas maps labels to the JSON Schema their node bodies must
+	// satisfy. See RegisterSchema.
+	schemas map[string]*jsonSchema
+	// relModel holds the declared legal relationship shapes. Empty means
+	// no enforcement. See AllowRelationship.
+	relModel map[relTriple]bool
+	// uniqueNames makes AddNode reject duplicate name+label pairs.
+	uniqueNames bool
+	// inverses maps relationship labels to their registered inverse, in
+	// both directions; maintainingInverse guards against recursing while
+	// the mirrored edge is written. See RegisterInverse.
+	inverses           map[string]string
+	maintainingInverse bool
+	// bodyIndexes holds the secondary indexes over JSON body fields. See
+	// CreateIndex.
+	bodyIndexes map[indexKey]*bodyIndex
+	// search is the inverted index behind Search.
+	search *searchIndex
+	// policies holds the invariants evaluated by CheckPolicies.
+	policies []policy
+	// name, description and meta identify the graph itself and travel with
+	// snapshots. See SetName, SetDescription and SetMeta.
+	name        string
+	description string
+	meta        map[string]string
+	// auditEnabled, actor and audit implement the mutation trail behind
+	// AuditLog. See WithAudit.
+	auditEnabled bool
+	actor        string
+	audit        []AuditEntry
+	// logger, when set with WithLogger, receives mutation and violation
+	// records.
+	logger *slog.Logger
+	// mvccEnabled, epoch, viewMu, view and viewEpoch implement the
+	// copy-on-write read snapshots behind WithMVCCReads. epoch is guarded
+	// by mu; the cached view by viewMu.
+	mvccEnabled bool
+	epoch       uint64
+	viewMu      sync.Mutex
+	view        *Graph
+	viewEpoch   uint64
+	// slowThreshold, slowMu and slowQueries implement the slow-traversal
+	// detector. See WithSlowQueryThreshold.
+	slowThreshold time.Duration
+	slowMu        sync.Mutex
+	slowQueries   []SlowQuery
+}
+
+// GraphOption configures a graph as it is created.
+type GraphOption func(*Graph)
+
+// NewGraph returns an empty graph ready for use.
+func NewGraph(opts ...GraphOption) *Graph {
+	graph := &Graph{
+		nodes:         map[string]Node{},
+		relationships: map[string]Relationship{},
+		relsByFrom:    map[string]map[string]bool{},
+		relsByTo:      map[string]map[string]bool{},
+		nodesByLabel:  map[string]map[string]bool{},
+		nodesByName:   map[string]map[string]bool{},
+		history:       map[string][]NodeRevision{},
+	}
+	for _, opt := range opts {
+		opt(graph)
+	}
+	return graph
+}
+
+// putNode stores a node and keeps the label index in sync. Callers must hold
+// the write lock.
+func (g *Graph) putNode(node Node) {
+	g.bumpEpoch()
+	if previous, ok := g.nodes[node.ID]; ok {
+		if previous.Label != node.Label {
+			g.unindexNodeLabel(previous)
+			g.unindexNode(previous)
+		}
+		if previous.Name != node.Name {
+			g.unindexNodeName(previous)
+		}
+	}
+	g.nodes[node.ID] = node
+	if g.nodesByLabel[node.Label] == nil {
+		g.nodesByLabel[node.Label] = map[string]bool{}
+	}
+	g.nodesByLabel[node.Label][node.ID] = true
+	if g.nodesByName[node.Name] == nil {
+		g.nodesByName[node.Name] = map[string]bool{}
+	}
+	g.nodesByName[node.Name][node.ID] = true
+	g.indexNode(node)
+	g.indexNodeText(node)
+}
+
+// removeNode deletes a node and keeps the label and name indexes in sync.
+// Callers must hold the write lock.
+func (g *Graph) removeNode(node Node) {
+	g.bumpEpoch()
+	delete(g.nodes, node.ID)
+	g.unindexNodeLabel(node)
+	g.unindexNodeName(node)
+	g.unindexNode(node)
+	g.unindexNodeText(node)
+}
+
+func (g *Graph) unindexNodeLabel(node Node) {
+	delete(g.nodesByLabel[node.Label], node.ID)
+	if len(g.nodesByLabel[node.Label]) == 0 {
+		delete(g.nodesByLabel, node.Label)
+	}
+}
+
+func (g *Graph) unindexNodeName(node Node) {
+	delete(g.nodesByName[node.Name], node.ID)
+	if len(g.nodesByName[node.Name]) == 0 {
+		delete(g.nodesByName, node.Name)
+	}
+}
+
+// putRelationship stores a relationship and keeps the adjacency indexes in
+// sync. Callers must hold the write lock.
+func (g *Graph) putRelationship(rel Relationship) {
+	g.bumpEpoch()
+	g.relationships[rel.ID] = rel
+	if g.relsByFrom[rel.From] == nil {
+		g.relsByFrom[rel.From] = map[string]bool{}
+	}
+	g.relsByFrom[rel.From][rel.ID] = true
+	if g.relsByTo[rel.To] == nil {
+		g.relsByTo[rel.To] = map[string]bool{}
+	}
+	g.relsByTo[rel.To][rel.ID] = true
+}
+
+// removeRelationship deletes a relationship and keeps the adjacency indexes
+// in sync. Callers must hold the write lock.
+func (g *Graph) removeRelationship(rel Relationship) {
+	g.bumpEpoch()
+	delete(g.relationships, rel.ID)
+	delete(g.relsByFrom[rel.From], rel.ID)
+	if len(g.relsByFrom[rel.From]) == 0 {
+		delete(g.relsByFrom, rel.From)
+	}
+	delete(g.relsByTo[rel.To], rel.ID)
+	if len(g.relsByTo[rel.To]) == 0 {
+		delete(g.relsByTo, rel.To)
+	}
+}
+
+// AddNode adds a new node to the graph and returns it with its generated ID.
+func (g *Graph) AddNode(name, label string, body []byte, opts ...NodeOption) (Node, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.addNode(name, label, body, opts)
+}
+
+// addNode implements AddNode for callers already holding the write lock.
+func (g *Graph) addNode(name, label string, body []byte, opts []NodeOption) (Node, error) {
+	timestamp := now()
+	node := Node{
+		ID:        newID(),
+		Name:      name,
+		Label:     label,
+		Body:      body,
+		Version:   1,
+		CreatedAt: timestamp,
+		UpdatedAt: timestamp,
+	}
+	for _, opt := range opts {
+		opt(&node)
+	}
+	if node.CreatedBy == "" {
+		node.CreatedBy = g.actor
+		node.UpdatedBy = g.actor
+	}
+	if err := runBefore(g.hooks.beforeInsertNode, &node); err != nil {
+		return Node{}, err
+	}
+	if err := g.validateBody(node.Label, node.Body); err != nil {
+		g.logEvent(slog.LevelWarn, "node insert rejected", "name", node.Name, "label", node.Label, "error", err)
+		return Node{}, err
+	}
+	if g.uniqueNames {
+		if _, err := g.lookupByNameAndLabel(node.Name, node.Label); err == nil {
+			return Node{}, fmt.Errorf("%w: node %q with label %q", ErrAlreadyExists, node.Name, node.Label)
+		}
+	}
+	g.putNode(node)
+	if err := g.logMutation(walEntry{Op: opInsertNode, Node: &node}); err != nil {
+		return Node{}, err
+	}
+	g.emitAfter(func() { runAfter(g.hooks.afterInsertNode, node) })
+	return node, nil
+}
+
+// UpdateNode replaces the body of the node with the given ID and returns the
+// updated node. ErrNotFound is returned when the node does not exist.
+func (g *Graph) UpdateNode(id string, body []byte) (Node, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.updateNode(id, body)
+}
+
+// updateNode implements UpdateNode for callers already holding the write
+// lock.
+func (g *Graph) updateNode(id string, body []byte) (Node, error) {
+	node, ok := g.nodes[id]
+	if !ok || node.isDeleted() {
+		return Node{}, ErrNotFound
+	}
+	g.recordRevision(node)
+	node.Body = body
+	node.Version++
+	node.UpdatedAt = now()
+	if g.actor != "" {
+		node.UpdatedBy = g.actor
+	}
+	if err := runBefore(g.hooks.beforeUpdateNode, &node); err != nil {
+		return Node{}, err
+	}
+	if err := g.validateBody(node.Label, node.Body); err != nil {
+		g.logEvent(slog.LevelWarn, "node update rejected", "id", node.ID, "label", node.Label, "error", err)
+		return Node{}, err
+	}
+	g.putNode(node)
+	if err := g.logMutation(walEntry{Op: opUpdateNode, Node: &node}); err != nil {
+		return Node{}, err
+	}
+	g.emitAfter(func() { runAfter(g.hooks.afterUpdateNode, node) })
+	return node, nil
+}
+
+// UpdateNodeIfVersion replaces the body of the node with the given ID only
+// when the node still carries the given version, so two concurrent writers
+// cannot silently clobber each other's update. ErrVersionMismatch is
+// returned when the node has moved on and ErrNotFound when it does not
+// exist.
+func (g *Graph) UpdateNodeIfVersion(id string, version uint64, body []byte) (Node, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	node, ok := g.nodes[id]
+	if !ok || node.isDeleted() {
+		return Node{}, ErrNotFound
+	}
+	if node.Version != version {
+		return Node{}, ErrVersionMismatch
+	}
+	return g.updateNode(id, body)
+}
+
+// UpdateNodeIf replaces the body of the node with the given ID only when its
+// current body byte-equals expected, as a simpler alternative to
+// version-based compare-and-swap when the caller has the old body at hand.
+// ErrBodyMismatch is returned when the body has moved on and ErrNotFound
+// when the node does not exist.
+func (g *Graph) UpdateNodeIf(id string, expected, body []byte) (Node, error) {
+	return g.UpdateNodeIfMatches(id, func(current []byte) bool {
+		return bytes.Equal(current, expected)
+	}, body)
+}
+
+// UpdateNodeIfMatches is UpdateNodeIf with an arbitrary predicate over the
+// current body, e.g. to compare only one field.
+func (g *Graph) UpdateNodeIfMatches(id string, matches func(current []byte) bool, body []byte) (Node, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	node, ok := g.nodes[id]
+	if !ok || node.isDeleted() {
+		return Node{}, ErrNotFound
+	}
+	if !matches(node.Body) {
+		return Node{}, ErrBodyMismatch
+	}
+	return g.updateNode(id, body)
+}
+
+// DeleteOption configures how DeleteNode treats relationships that still
+// reference the node being deleted.
+type DeleteOption func(*deleteOptions)
+
+type deleteOptions struct {
+	restrict bool
+}
+
+// DeleteCascade makes DeleteNode remove every relationship referencing the
+// deleted node. This is the default behavior.
+func DeleteCascade() DeleteOption {
+	return func(o *deleteOptions) {
+		o.restrict = false
+	}
+}
+
+// DeleteRestrict makes DeleteNode fail with ErrHasRelationships when
+// relationships still reference the node, leaving the graph unchanged.
+func DeleteRestrict() DeleteOption {
+	return func(o *deleteOptions) {
+		o.restrict = true
+	}
+}
+
+// DeleteNode removes the node with the given ID from the graph, cascading to
+// any relationships referencing it unless DeleteRestrict is set. ErrNotFound
+// is returned when the node does not exist.
+func (g *Graph) DeleteNode(id string, opts ...DeleteOption) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.deleteNode(id, opts)
+}
+
+// deleteNode implements DeleteNode for callers already holding the write
+// lock.
+func (g *Graph) deleteNode(id string, opts []DeleteOption) error {
+	node, ok := g.nodes[id]
+	if !ok || node.isDeleted() {
+		return ErrNotFound
+	}
+	if g.softDelete {
+		if err := runBeforeValue(g.hooks.beforeDeleteNode, node); err != nil {
+			return err
+		}
+		timestamp := now()
+		node.DeletedAt = &timestamp
+		node.UpdatedAt = timestamp
+		g.putNode(node)
+		if err := g.logMutation(walEntry{Op: opUpdateNode, Node: &node}); err != nil {
+			return err
+		}
+		g.emitAfter(func() { runAfter(g.hooks.afterDeleteNode, node) })
+		return nil
+	}
+	options := deleteOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	cascade := []Relationship{}
+	seen := map[string]bool{}
+	for relID := range g.relsByFrom[id] {
+		seen[relID] = true
+		cascade = append(cascade, g.relationships[relID])
+	}
+	for relID := range g.relsByTo[id] {
+		if !seen[relID] {
+			cascade = append(cascade, g.relationships[relID])
+		}
+	}
+	if options.restrict && len(cascade) > 0 {
+		return ErrHasRelationships
+	}
+	if err := runBeforeValue(g.hooks.beforeDeleteNode, node); err != nil {
+		return err
+	}
+	for _, rel := range cascade {
+		if err := runBeforeValue(g.hooks.beforeDeleteRel, rel); err != nil {
+			return err
+		}
+	}
+	for _, rel := range cascade {
+		g.removeRelationship(rel)
+	}
+	g.removeNode(node)
+	delete(g.history, id)
+	if err := g.logMutation(walEntry{Op: opDeleteNode, ID: id}); err != nil {
+		return err
+	}
+	for _, rel := range cascade {
+		rel := rel
+		g.emitAfter(func() { runAfter(g.hooks.afterDeleteRel, rel) })
+	}
+	g.emitAfter(func() { runAfter(g.hooks.afterDeleteNode, node) })
+	return nil
+}
+
+// GetNodeByID returns the node with the given ID, or ErrNotFound when no such
+// node exists.
+func (g *Graph) GetNodeByID(id string) (Node, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.getNodeByID(id)
+}
+
+func (g *Graph) getNodeByID(id string) (Node, error) {
+	node, ok := g.nodes[id]
+	if !ok || !g.nodeVisible(node) {
+		return Node{}, ErrNotFound
+	}
+	return node, nil
+}
+
+// nodeVisible reports whether a node should be returned by reads: neither
+// soft-deleted nor past its TTL.
+func (g *Graph) nodeVisible(node Node) bool {
+	return !node.isDeleted() && !node.expiredAt(now())
+}
+
+// GetNodesByName returns every node with the given name. It reads the name
+// index, so its cost is proportional to the number of matches.
+func (g *Graph) GetNodesByName(name string) []Node {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	nodes := []Node{}
+	for id := range g.nodesByName[name] {
+		if node := g.nodes[id]; g.nodeVisible(node) {
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes
+}
+
+// GetNodeByNameAndLabel returns the node carrying both the given name and
+// label, or ErrNotFound when no such node exists.
+func (g *Graph) GetNodeByNameAndLabel(name, label string) (Node, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.lookupByNameAndLabel(name, label)
+}
+
+// ListNodes returns every node matching all of the given filters.
+// Soft-deleted nodes are skipped unless IncludeDeleted is passed.
+func (g *Graph) ListNodes(filters ...Filter) []Node {
+	if view := g.stableView(); view != nil {
+		return view.ListNodes(filters...)
+	}
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	includeDeleted, filters := splitIncludeDeleted(filters)
+	nodes := []Node{}
+	for _, node := range g.nodes {
+		if node.isDeleted() && !includeDeleted {
+			continue
+		}
+		if node.expiredAt(now()) {
+			continue
+		}
+		if matchesNode(node, filters) {
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes
+}
+
+// ListNodesByLabel returns every node with the given label that also matches
+// all of the given filters. It reads the label index, so its cost is
+// proportional to the number of nodes carrying the label rather than the size
+// of the graph.
+func (g *Graph) ListNodesByLabel(label string, filters ...Filter) []Node {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	includeDeleted, filters := splitIncludeDeleted(filters)
+	nodes := []Node{}
+	for id := range g.nodesByLabel[label] {
+		node := g.nodes[id]
+		if node.isDeleted() && !includeDeleted {
+			continue
+		}
+		if node.expiredAt(now()) {
+			continue
+		}
+		if matchesNode(node, filters) {
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes
+}
+
+// AddRelationship adds a directed, labeled relationship between two nodes and
+// returns it with its generated ID. ErrNotFound is returned when either node
+// is not part of the graph.
+func (g *Graph) AddRelationship(from, to Node, label string, opts ...RelOption) (Relationship, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.addRelationship(from, to, label, opts)
+}
+
+// AddUndirectedRelationship adds a single relationship between a and b that
+// traversals follow in both directions, for relations like "peered_with"
+// that have no natural direction. One edge is stored; a and b become its
+// From and To only as a record of how it was inserted.
+func (g *Graph) AddUndirectedRelationship(a, b Node, label string, opts ...RelOption) (Relationship, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	opts = append(opts, func(r *Relationship) {
+		r.Undirected = true
+	})
+	return g.addRelationship(a, b, label, opts)
+}
+
+// addRelationship implements AddRelationship for callers already holding the
+// write lock.
+func (g *Graph) addRelationship(from, to Node, label string, opts []RelOption) (Relationship, error) {
+	fromNode, err := g.getNodeByID(from.ID)
+	if err != nil {
+		return Relationship{}, err
+	}
+	toNode, err := g.getNodeByID(to.ID)
+	if err != nil {
+		return Relationship{}, err
+	}
+	if err := g.checkRelationshipAllowed(fromNode, toNode, label); err != nil {
+		g.logEvent(slog.LevelWarn, "relationship rejected",
+			"from", fromNode.ID, "to", toNode.ID, "label", label, "error", err)
+		return Relationship{}, err
+	}
+	timestamp := now()
+	rel := Relationship{
+		ID:        newID(),
+		From:      from.ID,
+		To:        to.ID,
+		Label:     label,
+		CreatedAt: timestamp,
+		UpdatedAt: timestamp,
+	}
+	for _, opt := range opts {
+		opt(&rel)
+	}
+	if rel.CreatedBy == "" {
+		rel.CreatedBy = g.actor
+		rel.UpdatedBy = g.actor
+	}
+	if err := runBefore(g.hooks.beforeAddRel, &rel); err != nil {
+		return Relationship{}, err
+	}
+	g.putRelationship(rel)
+	if err := g.logMutation(walEntry{Op: opAddRelationship, Relationship: &rel}); err != nil {
+		return Relationship{}, err
+	}
+	g.emitAfter(func() { runAfter(g.hooks.afterAddRel, rel) })
+	if err := g.maintainInverseAdd(rel); err != nil {
+		return Relationship{}, err
+	}
+	return rel, nil
+}
+
+// UpdateRelationship applies the given updates to the relationship with the
+// given ID, keeping its ID and endpoints intact, and returns the updated
+// relationship. ErrNotFound is returned when the relationship does not exist.
+func (g *Graph) UpdateRelationship(id string, opts ...RelUpdateOption) (Relationship, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.updateRelationship(id, opts)
+}
+
+// updateRelationship implements UpdateRelationship for callers already
+// holding the write lock.
+func (g *Graph) updateRelationship(id string, opts []RelUpdateOption) (Relationship, error) {
+	rel, ok := g.relationships[id]
+	if !ok {
+		return Relationship{}, ErrNotFound
+	}
+	for _, opt := range opts {
+		opt(&rel)
+	}
+	rel.UpdatedAt = now()
+	if g.actor != "" {
+		rel.UpdatedBy = g.actor
+	}
+	if err := runBefore(g.hooks.beforeUpdateRel, &rel); err != nil {
+		return Relationship{}, err
+	}
+	g.bumpEpoch()
+	g.relationships[id] = rel
+	if err := g.logMutation(walEntry{Op: opUpdateRelationship, Relationship: &rel}); err != nil {
+		return Relationship{}, err
+	}
+	g.emitAfter(func() { runAfter(g.hooks.afterUpdateRel, rel) })
+	return rel, nil
+}
+
+// DeleteRelationship removes the relationship with the given ID from the
+// graph. ErrNotFound is returned when the relationship does not exist.
+func (g *Graph) DeleteRelationship(id string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.deleteRelationship(id)
+}
+
+// deleteRelationship implements DeleteRelationship for callers already
+// holding the write lock.
+func (g *Graph) deleteRelationship(id string) error {
+	rel, ok := g.relationships[id]
+	if !ok {
+		return ErrNotFound
+	}
+	if err := runBeforeValue(g.hooks.beforeDeleteRel, rel); err != nil {
+		return err
+	}
+	g.removeRelationship(rel)
+	if err := g.logMutation(walEntry{Op: opDeleteRelationship, ID: id}); err != nil {
+		return err
+	}
+	g.emitAfter(func() { runAfter(g.hooks.afterDeleteRel, rel) })
+	return g.maintainInverseDelete(rel)
+}
+
+// DeleteRelationships removes every relationship matching all of the given
+// filters and returns the number of relationships removed. A failed
+// write-ahead log append aborts the sweep, so the count reflects what was
+// both removed and logged.
+func (g *Graph) DeleteRelationships(filters ...FilterRelationship) (int, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	deleted := 0
+	for _, rel := range g.relationships {
+		if !matchesRelationship(rel, filters) {
+			continue
+		}
+		if err := runBeforeValue(g.hooks.beforeDeleteRel, rel); err != nil {
+			continue
+		}
+		rel := rel
+		g.removeRelationship(rel)
+		if err := g.logMutation(walEntry{Op: opDeleteRelationship, ID: rel.ID}); err != nil {
+			return deleted, err
+		}
+		g.emitAfter(func() { runAfter(g.hooks.afterDeleteRel, rel) })
+		deleted++
+	}
+	return deleted, nil
+}
+
+// ListRelationships returns every relationship matching all of the given
+// filters.
+func (g *Graph) ListRelationships(filters ...FilterRelationship) []Relationship {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.listRelationships(filters)
+}
+
+// RelationshipsOf returns every relationship touching the node with the
+// given ID, incoming and outgoing alike. It reads the adjacency indexes, so
+// its cost is proportional to the degree of the node.
+func (g *Graph) RelationshipsOf(nodeID string) []Relationship {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	relationships := []Relationship{}
+	cutoff := now()
+	seen := map[string]bool{}
+	appendRels := func(relIDs map[string]bool) {
+		for relID := range relIDs {
+			if seen[relID] {
+				continue
+			}
+			seen[relID] = true
+			if rel := g.relationships[relID]; !rel.expiredAt(cutoff) {
+				relationships = append(relationships, rel)
+			}
+		}
+	}
+	appendRels(g.relsByFrom[nodeID])
+	appendRels(g.relsByTo[nodeID])
+	return relationships
+}
+
+// GetRelationshipsBetween returns the relationships running from the first
+// node to the second. It reads the adjacency index, so its cost is
+// proportional to the degree of the from node rather than the size of the
+// graph.
+func (g *Graph) GetRelationshipsBetween(fromID, toID string) []Relationship {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	relationships := []Relationship{}
+	cutoff := now()
+	for relID := range g.relsByFrom[fromID] {
+		rel := g.relationships[relID]
+		if rel.To == toID && !rel.expiredAt(cutoff) {
+			relationships = append(relationships, rel)
+		}
+	}
+	return relationships
+}
+
+// HasRelationship reports whether a relationship runs from the first node to
+// the second. With labels given, only relationships carrying one of those
+// labels count.
+func (g *Graph) HasRelationship(fromID, toID string, labels ...string) bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	cutoff := now()
+	for relID := range g.relsByFrom[fromID] {
+		rel := g.relationships[relID]
+		if rel.To != toID || rel.expiredAt(cutoff) {
+			continue
+		}
+		if len(labels) == 0 {
+			return true
+		}
+		for _, label := range labels {
+			if rel.Label == label {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (g *Graph) listRelationships(filters []FilterRelationship) []Relationship {
+	relationships := []Relationship{}
+	cutoff := now()
+	for _, rel := range g.relationships {
+		if rel.expiredAt(cutoff) {
+			continue
+		}
+		if matchesRelationship(rel, filters) {
+			relationships = append(relationships, rel)
+		}
+	}
+	return relationships
+}
+
+func newID() string {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(buf)
+}
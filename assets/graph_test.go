@@ -0,0 +1,166 @@
+package assets
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestAddAndGetNode(t *testing.T) {
+	g := NewGraph()
+	node, err := g.AddNode("web-01", "server", []byte(`{"cpu":4}`))
+	if err != nil {
+		t.Fatalf("AddNode: %v", err)
+	}
+	if node.ID == "" {
+		t.Fatal("AddNode returned a node without an ID")
+	}
+	got, err := g.GetNodeByID(node.ID)
+	if err != nil {
+		t.Fatalf("GetNodeByID: %v", err)
+	}
+	if got.Name != "web-01" || got.Label != "server" || string(got.Body) != `{"cpu":4}` {
+		t.Errorf("got node %+v, want name web-01, label server, original body", got)
+	}
+	if _, err := g.GetNodeByID("missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetNodeByID on missing ID: got %v, want ErrNotFound", err)
+	}
+}
+
+func TestNodeTimestampsUseStubbedClock(t *testing.T) {
+	created := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	updated := created.Add(time.Hour)
+	restore := now
+	defer func() { now = restore }()
+
+	now = func() time.Time { return created }
+	g := NewGraph()
+	node, err := g.AddNode("db-01", "server", nil)
+	if err != nil {
+		t.Fatalf("AddNode: %v", err)
+	}
+	if !node.CreatedAt.Equal(created) || !node.UpdatedAt.Equal(created) {
+		t.Errorf("new node timestamps = %v/%v, want %v", node.CreatedAt, node.UpdatedAt, created)
+	}
+
+	now = func() time.Time { return updated }
+	node, err = g.UpdateNode(node.ID, []byte(`{"cpu":8}`))
+	if err != nil {
+		t.Fatalf("UpdateNode: %v", err)
+	}
+	if !node.CreatedAt.Equal(created) {
+		t.Errorf("update changed CreatedAt to %v, want %v", node.CreatedAt, created)
+	}
+	if !node.UpdatedAt.Equal(updated) {
+		t.Errorf("UpdatedAt = %v, want %v", node.UpdatedAt, updated)
+	}
+}
+
+func TestUpdateNodeBumpsVersion(t *testing.T) {
+	g := NewGraph()
+	node, err := g.AddNode("web-01", "server", nil)
+	if err != nil {
+		t.Fatalf("AddNode: %v", err)
+	}
+	updated, err := g.UpdateNode(node.ID, []byte(`{"cpu":8}`))
+	if err != nil {
+		t.Fatalf("UpdateNode: %v", err)
+	}
+	if updated.Version != node.Version+1 {
+		t.Errorf("version after update = %d, want %d", updated.Version, node.Version+1)
+	}
+	if _, err := g.UpdateNodeIfVersion(node.ID, node.Version, nil); !errors.Is(err, ErrVersionMismatch) {
+		t.Errorf("UpdateNodeIfVersion with stale version: got %v, want ErrVersionMismatch", err)
+	}
+}
+
+func TestUniqueNames(t *testing.T) {
+	g := NewGraph(WithUniqueNames())
+	if _, err := g.AddNode("web-01", "server", nil); err != nil {
+		t.Fatalf("AddNode: %v", err)
+	}
+	if _, err := g.AddNode("web-01", "server", nil); !errors.Is(err, ErrAlreadyExists) {
+		t.Errorf("duplicate AddNode: got %v, want ErrAlreadyExists", err)
+	}
+	// The same name under a different label is a different asset.
+	if _, err := g.AddNode("web-01", "dns-record", nil); err != nil {
+		t.Errorf("AddNode with same name, different label: %v", err)
+	}
+}
+
+func TestDeleteNodeRestrictAndCascade(t *testing.T) {
+	g := NewGraph()
+	from, _ := g.AddNode("web-01", "server", nil)
+	to, _ := g.AddNode("vpc-1", "network", nil)
+	rel, err := g.AddRelationship(from, to, "in-network")
+	if err != nil {
+		t.Fatalf("AddRelationship: %v", err)
+	}
+	if err := g.DeleteNode(from.ID, DeleteRestrict()); !errors.Is(err, ErrHasRelationships) {
+		t.Errorf("DeleteNode restrict with edges: got %v, want ErrHasRelationships", err)
+	}
+	if err := g.DeleteNode(from.ID, DeleteCascade()); err != nil {
+		t.Fatalf("DeleteNode cascade: %v", err)
+	}
+	if _, err := g.GetNodeByID(from.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("deleted node still readable: %v", err)
+	}
+	if rels := g.ListRelationships(FilterRelByLabel(rel.Label)); len(rels) != 0 {
+		t.Errorf("cascade left %d relationships behind", len(rels))
+	}
+}
+
+func TestListNodesByLabel(t *testing.T) {
+	g := NewGraph()
+	g.AddNode("web-01", "server", nil)
+	g.AddNode("web-02", "server", nil)
+	g.AddNode("vpc-1", "network", nil)
+	servers := g.ListNodesByLabel("server")
+	if len(servers) != 2 {
+		t.Fatalf("ListNodesByLabel(server) returned %d nodes, want 2", len(servers))
+	}
+	for _, node := range servers {
+		if node.Label != "server" {
+			t.Errorf("ListNodesByLabel returned node with label %q", node.Label)
+		}
+	}
+}
+
+func TestRelationshipsBetween(t *testing.T) {
+	g := NewGraph()
+	from, _ := g.AddNode("web-01", "server", nil)
+	to, _ := g.AddNode("vpc-1", "network", nil)
+	if _, err := g.AddRelationship(from, to, "in-network"); err != nil {
+		t.Fatalf("AddRelationship: %v", err)
+	}
+	if !g.HasRelationship(from.ID, to.ID, "in-network") {
+		t.Error("HasRelationship did not find the added edge")
+	}
+	if g.HasRelationship(to.ID, from.ID, "in-network") {
+		t.Error("HasRelationship matched the reverse direction of a directed edge")
+	}
+	between := g.GetRelationshipsBetween(from.ID, to.ID)
+	if len(between) != 1 || between[0].Label != "in-network" {
+		t.Errorf("GetRelationshipsBetween returned %+v, want one in-network edge", between)
+	}
+}
+
+func TestSoftDeleteAndRestore(t *testing.T) {
+	g := NewGraph(WithSoftDelete())
+	node, _ := g.AddNode("web-01", "server", nil)
+	if err := g.DeleteNode(node.ID); err != nil {
+		t.Fatalf("DeleteNode: %v", err)
+	}
+	if _, err := g.GetNodeByID(node.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("soft-deleted node visible to reads: %v", err)
+	}
+	if nodes := g.ListNodes(IncludeDeleted()); len(nodes) != 1 {
+		t.Errorf("ListNodes(IncludeDeleted) returned %d nodes, want 1", len(nodes))
+	}
+	if _, err := g.RestoreNode(node.ID); err != nil {
+		t.Fatalf("RestoreNode: %v", err)
+	}
+	if _, err := g.GetNodeByID(node.ID); err != nil {
+		t.Errorf("restored node not readable: %v", err)
+	}
+}
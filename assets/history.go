@@ -0,0 +1,64 @@
+package assets
+
+import "time"
+
+// NodeRevision is a previous state of a node, captured before an update
+// replaced it.
+type NodeRevision struct {
+	Version   uint64    `json:"version"`
+	Body      []byte    `json:"body,omitempty"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// WithHistory makes the graph keep up to limit previous revisions per node,
+// capturing the old body every time a node is updated. A limit of zero or
+// less keeps every revision. History is required for NodeHistory, RevertNode
+// and as-of queries.
+func WithHistory(limit int) GraphOption {
+	return func(g *Graph) {
+		g.historyEnabled = true
+		g.historyLimit = limit
+	}
+}
+
+// NodeHistory returns the recorded previous revisions of the node with the
+// given ID, oldest first. The slice is a copy and safe to retain.
+func (g *Graph) NodeHistory(id string) []NodeRevision {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	revisions := make([]NodeRevision, len(g.history[id]))
+	copy(revisions, g.history[id])
+	return revisions
+}
+
+// RevertNode restores the body the node had at the given revision version,
+// applied as a regular update so the version keeps increasing and the change
+// is logged and hooked like any other. ErrNotFound is returned when the node
+// or the revision does not exist.
+func (g *Graph) RevertNode(id string, version uint64) (Node, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for _, revision := range g.history[id] {
+		if revision.Version == version {
+			return g.updateNode(id, revision.Body)
+		}
+	}
+	return Node{}, ErrNotFound
+}
+
+// recordRevision archives the current state of a node ahead of an update.
+// Callers must hold the write lock.
+func (g *Graph) recordRevision(node Node) {
+	if !g.historyEnabled {
+		return
+	}
+	revisions := append(g.history[node.ID], NodeRevision{
+		Version:   node.Version,
+		Body:      node.Body,
+		UpdatedAt: node.UpdatedAt,
+	})
+	if g.historyLimit > 0 && len(revisions) > g.historyLimit {
+		revisions = revisions[len(revisions)-g.historyLimit:]
+	}
+	g.history[node.ID] = revisions
+}
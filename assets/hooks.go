@@ -0,0 +1,144 @@
+package assets
+
+// Mutation hooks let callers observe or veto changes to the graph without
+// wrapping every call site, enabling validation, enrichment and audit
+// logging. Before-hooks run ahead of the mutation and may change the element
+// in place or abort the mutation by returning an error; after-hooks run once
+// the mutation has been applied.
+//
+// Hooks run while the graph lock is held so they observe a consistent state;
+// they must not call back into the graph or they will deadlock.
+
+// hooks holds the registered mutation hooks of a graph.
+type hooks struct {
+	beforeInsertNode []func(*Node) error
+	afterInsertNode  []func(Node)
+	beforeUpdateNode []func(*Node) error
+	afterUpdateNode  []func(Node)
+	beforeDeleteNode []func(Node) error
+	afterDeleteNode  []func(Node)
+	beforeAddRel     []func(*Relationship) error
+	afterAddRel      []func(Relationship)
+	beforeUpdateRel  []func(*Relationship) error
+	afterUpdateRel   []func(Relationship)
+	beforeDeleteRel  []func(Relationship) error
+	afterDeleteRel   []func(Relationship)
+	expired          []func(Node)
+}
+
+// OnBeforeInsert registers a hook running before a node is inserted.
+func (g *Graph) OnBeforeInsert(fn func(*Node) error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.hooks.beforeInsertNode = append(g.hooks.beforeInsertNode, fn)
+}
+
+// OnAfterInsert registers a hook running after a node has been inserted.
+func (g *Graph) OnAfterInsert(fn func(Node)) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.hooks.afterInsertNode = append(g.hooks.afterInsertNode, fn)
+}
+
+// OnBeforeUpdate registers a hook running before a node is updated; the hook
+// receives the node carrying its new state.
+func (g *Graph) OnBeforeUpdate(fn func(*Node) error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.hooks.beforeUpdateNode = append(g.hooks.beforeUpdateNode, fn)
+}
+
+// OnAfterUpdate registers a hook running after a node has been updated.
+func (g *Graph) OnAfterUpdate(fn func(Node)) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.hooks.afterUpdateNode = append(g.hooks.afterUpdateNode, fn)
+}
+
+// OnBeforeDelete registers a hook running before a node is deleted.
+func (g *Graph) OnBeforeDelete(fn func(Node) error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.hooks.beforeDeleteNode = append(g.hooks.beforeDeleteNode, fn)
+}
+
+// OnAfterDelete registers a hook running after a node has been deleted.
+func (g *Graph) OnAfterDelete(fn func(Node)) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.hooks.afterDeleteNode = append(g.hooks.afterDeleteNode, fn)
+}
+
+// OnBeforeAddRelationship registers a hook running before a relationship is
+// added.
+func (g *Graph) OnBeforeAddRelationship(fn func(*Relationship) error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.hooks.beforeAddRel = append(g.hooks.beforeAddRel, fn)
+}
+
+// OnAfterAddRelationship registers a hook running after a relationship has
+// been added.
+func (g *Graph) OnAfterAddRelationship(fn func(Relationship)) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.hooks.afterAddRel = append(g.hooks.afterAddRel, fn)
+}
+
+// OnBeforeUpdateRelationship registers a hook running before a relationship
+// is updated; the hook receives the relationship carrying its new state.
+func (g *Graph) OnBeforeUpdateRelationship(fn func(*Relationship) error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.hooks.beforeUpdateRel = append(g.hooks.beforeUpdateRel, fn)
+}
+
+// OnAfterUpdateRelationship registers a hook running after a relationship
+// has been updated.
+func (g *Graph) OnAfterUpdateRelationship(fn func(Relationship)) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.hooks.afterUpdateRel = append(g.hooks.afterUpdateRel, fn)
+}
+
+// OnBeforeDeleteRelationship registers a hook running before a relationship
+// is deleted. It also runs for relationships removed by a cascading node
+// delete; returning an error there aborts the whole delete.
+func (g *Graph) OnBeforeDeleteRelationship(fn func(Relationship) error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.hooks.beforeDeleteRel = append(g.hooks.beforeDeleteRel, fn)
+}
+
+// OnAfterDeleteRelationship registers a hook running after a relationship
+// has been deleted, including relationships removed by a cascading node
+// delete.
+func (g *Graph) OnAfterDeleteRelationship(fn func(Relationship)) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.hooks.afterDeleteRel = append(g.hooks.afterDeleteRel, fn)
+}
+
+func runBefore[T any](hooks []func(*T) error, element *T) error {
+	for _, hook := range hooks {
+		if err := hook(element); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func runBeforeValue[T any](hooks []func(T) error, element T) error {
+	for _, hook := range hooks {
+		if err := hook(element); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func runAfter[T any](hooks []func(T), element T) {
+	for _, hook := range hooks {
+		hook(element)
+	}
+}
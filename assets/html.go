@@ -0,0 +1,239 @@
+package assets
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// VizOption configures an ExportHTML call.
+type VizOption func(*vizOptions)
+
+type vizOptions struct {
+	title   string
+	filters []Filter
+}
+
+// WithVizTitle sets the page title of the exported visualization.
+func WithVizTitle(title string) VizOption {
+	return func(o *vizOptions) {
+		o.title = title
+	}
+}
+
+// WithVizFilters restricts the visualization to nodes matching every filter,
+// together with the relationships between them.
+func WithVizFilters(filters ...Filter) VizOption {
+	return func(o *vizOptions) {
+		o.filters = filters
+	}
+}
+
+// vizNode and vizEdge are the shapes handed to the embedded script.
+type vizNode struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Label string `json:"label"`
+	Body  string `json:"body,omitempty"`
+}
+
+type vizEdge struct {
+	From  string `json:"from"`
+	To    string `json:"to"`
+	Label string `json:"label"`
+}
+
+// ExportHTML writes a self-contained HTML page rendering the graph with a
+// force-directed layout: no external assets, nodes colored by label, and
+// clicking a node shows its body. It is meant for ad-hoc exploration of
+// small and medium graphs; very large graphs are better served by the DOT
+// and GraphML exports and dedicated tooling.
+func (g *Graph) ExportHTML(w io.Writer, opts ...VizOption) error {
+	options := vizOptions{title: "asset graph"}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	g.mu.RLock()
+	included := map[string]bool{}
+	nodes := []vizNode{}
+	for _, node := range sortedNodes(g.nodes) {
+		if !g.nodeVisible(node) || !matchesNode(node, options.filters) {
+			continue
+		}
+		included[node.ID] = true
+		nodes = append(nodes, vizNode{ID: node.ID, Name: node.Name, Label: node.Label, Body: string(node.Body)})
+	}
+	edges := []vizEdge{}
+	for _, rel := range sortedRelationships(g.relationships) {
+		if !included[rel.From] || !included[rel.To] {
+			continue
+		}
+		edges = append(edges, vizEdge{From: rel.From, To: rel.To, Label: rel.Label})
+	}
+	name := g.name
+	g.mu.RUnlock()
+	if name != "" && options.title == "asset graph" {
+		options.title = name
+	}
+	nodesJSON, err := json.Marshal(nodes)
+	if err != nil {
+		return err
+	}
+	edgesJSON, err := json.Marshal(edges)
+	if err != nil {
+		return err
+	}
+	titleJSON, err := json.Marshal(options.title)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, vizPage, titleJSON, nodesJSON, edgesJSON)
+	return err
+}
+
+// vizPage is the page template. The three verbs receive the JSON-encoded
+// title, node list and edge list; everything else, including the force
+// simulation, is inline so the file works offline.
+const vizPage = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>asset graph</title>
+<style>
+  body { margin: 0; font-family: sans-serif; display: flex; height: 100vh; }
+  #canvas { flex: 1; }
+  #panel { width: 320px; border-left: 1px solid #ccc; padding: 12px; overflow: auto; }
+  #panel h2 { margin-top: 0; font-size: 16px; }
+  #panel pre { white-space: pre-wrap; word-break: break-all; font-size: 12px; }
+  .legend span { display: inline-block; margin-right: 10px; font-size: 12px; }
+  .legend i { display: inline-block; width: 10px; height: 10px; border-radius: 5px; margin-right: 3px; }
+</style>
+</head>
+<body>
+<canvas id="canvas"></canvas>
+<div id="panel"><h2 id="title"></h2><div class="legend" id="legend"></div><div id="detail">Click a node.</div></div>
+<script>
+const title = %s;
+const nodes = %s;
+const edges = %s;
+document.getElementById("title").textContent = title;
+document.title = title;
+
+const colorOf = label => {
+  let h = 0;
+  for (const c of label) h = (h * 31 + c.charCodeAt(0)) %% 360;
+  return "hsl(" + h + ",65%%,55%%)";
+};
+const legend = document.getElementById("legend");
+for (const label of [...new Set(nodes.map(n => n.label))].sort()) {
+  const s = document.createElement("span");
+  const dot = document.createElement("i");
+  dot.style.background = colorOf(label);
+  s.appendChild(dot);
+  s.appendChild(document.createTextNode(label));
+  legend.appendChild(s);
+}
+
+const canvas = document.getElementById("canvas");
+const ctx = canvas.getContext("2d");
+const byId = {};
+for (const n of nodes) {
+  n.x = Math.random() * 800; n.y = Math.random() * 600; n.vx = 0; n.vy = 0;
+  byId[n.id] = n;
+}
+let selected = null;
+
+function resize() {
+  canvas.width = canvas.clientWidth;
+  canvas.height = canvas.clientHeight;
+}
+window.addEventListener("resize", resize);
+
+function tick() {
+  // Repulsion between every pair, springs along edges, gravity to center.
+  for (const a of nodes) {
+    for (const b of nodes) {
+      if (a === b) continue;
+      const dx = a.x - b.x, dy = a.y - b.y;
+      const d2 = Math.max(dx * dx + dy * dy, 25);
+      const f = 1200 / d2;
+      a.vx += dx / Math.sqrt(d2) * f;
+      a.vy += dy / Math.sqrt(d2) * f;
+    }
+  }
+  for (const e of edges) {
+    const a = byId[e.from], b = byId[e.to];
+    if (!a || !b) continue;
+    const dx = b.x - a.x, dy = b.y - a.y;
+    const d = Math.max(Math.sqrt(dx * dx + dy * dy), 1);
+    const f = (d - 90) * 0.02;
+    a.vx += dx / d * f; a.vy += dy / d * f;
+    b.vx -= dx / d * f; b.vy -= dy / d * f;
+  }
+  const cx = canvas.width / 2, cy = canvas.height / 2;
+  for (const n of nodes) {
+    n.vx += (cx - n.x) * 0.002;
+    n.vy += (cy - n.y) * 0.002;
+    n.vx *= 0.85; n.vy *= 0.85;
+    n.x += n.vx; n.y += n.vy;
+  }
+}
+
+function draw() {
+  ctx.clearRect(0, 0, canvas.width, canvas.height);
+  ctx.strokeStyle = "#bbb";
+  ctx.fillStyle = "#888";
+  ctx.font = "10px sans-serif";
+  for (const e of edges) {
+    const a = byId[e.from], b = byId[e.to];
+    if (!a || !b) continue;
+    ctx.beginPath();
+    ctx.moveTo(a.x, a.y);
+    ctx.lineTo(b.x, b.y);
+    ctx.stroke();
+    if (e.label) ctx.fillText(e.label, (a.x + b.x) / 2, (a.y + b.y) / 2);
+  }
+  for (const n of nodes) {
+    ctx.beginPath();
+    ctx.arc(n.x, n.y, n === selected ? 9 : 6, 0, Math.PI * 2);
+    ctx.fillStyle = colorOf(n.label);
+    ctx.fill();
+    if (n === selected) { ctx.strokeStyle = "#000"; ctx.stroke(); }
+    ctx.fillStyle = "#333";
+    ctx.fillText(n.name, n.x + 8, n.y + 3);
+  }
+}
+
+canvas.addEventListener("click", ev => {
+  const rect = canvas.getBoundingClientRect();
+  const x = ev.clientX - rect.left, y = ev.clientY - rect.top;
+  selected = null;
+  for (const n of nodes) {
+    if ((n.x - x) ** 2 + (n.y - y) ** 2 < 100) { selected = n; break; }
+  }
+  const detail = document.getElementById("detail");
+  if (!selected) { detail.textContent = "Click a node."; return; }
+  let body = selected.body;
+  try { body = JSON.stringify(JSON.parse(body), null, 2); } catch (e) {}
+  // Node names, labels and bodies come from external importers, so they
+  // are only ever rendered through textContent, never markup.
+  detail.textContent = "";
+  const name = document.createElement("b");
+  name.textContent = selected.name;
+  const id = document.createElement("small");
+  id.textContent = selected.id;
+  const pre = document.createElement("pre");
+  pre.textContent = body || "(no body)";
+  detail.appendChild(name);
+  detail.appendChild(document.createTextNode(" (" + selected.label + ")"));
+  detail.appendChild(document.createElement("br"));
+  detail.appendChild(id);
+  detail.appendChild(pre);
+});
+
+resize();
+setInterval(() => { tick(); draw(); }, 30);
+</script>
+</body>
+</html>
+`
@@ -0,0 +1,340 @@
+// Package aws imports AWS resources into an asset graph. It consumes the
+// JSON documents the AWS CLI and SDK emit — `aws ec2 describe-instances`,
+// `describe-security-groups`, `describe-vpcs`, `rds describe-db-instances`,
+// `s3api list-buckets`, `iam list-roles` — so it needs no AWS dependency
+// itself, and wires the in-vpc, attached-to and can-assume relationships
+// between what it finds.
+package aws
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/mimatache/asset-graph/assets"
+)
+
+// Node labels created by the importer.
+const (
+	LabelInstance      = "aws-ec2-instance"
+	LabelSecurityGroup = "aws-security-group"
+	LabelVPC           = "aws-vpc"
+	LabelRDSInstance   = "aws-rds-instance"
+	LabelS3Bucket      = "aws-s3-bucket"
+	LabelIAMRole       = "aws-iam-role"
+)
+
+// Relationship labels created by the importer.
+const (
+	RelInVPC      = "in-vpc"
+	RelAttachedTo = "attached-to"
+	RelCanAssume  = "can-assume"
+)
+
+// Importer populates a graph from AWS API responses. Resources can be
+// imported in any order; relationships are wired as both endpoints become
+// known.
+type Importer struct {
+	graph *assets.Graph
+	// byAWSID resolves VPC IDs, group IDs and role ARNs to graph nodes
+	// across successive import calls.
+	byAWSID map[string]assets.Node
+	// pendingVPCs maps a resource node ID to the VPC ID it belongs to and
+	// pendingGroups to the security group IDs attached to it; both are
+	// replayed whenever new resources appear, so import order does not
+	// matter.
+	pendingVPCs   map[string]string
+	pendingGroups map[string][]string
+	// pendingTrusts maps a role node ID to the principal ARNs its trust
+	// policy names, replayed whenever new roles appear.
+	pendingTrusts map[string][]string
+}
+
+// NewImporter returns an importer inserting into the given graph.
+func NewImporter(g *assets.Graph) *Importer {
+	return &Importer{
+		graph:         g,
+		byAWSID:       map[string]assets.Node{},
+		pendingVPCs:   map[string]string{},
+		pendingGroups: map[string][]string{},
+		pendingTrusts: map[string][]string{},
+	}
+}
+
+// ImportVPCs reads a describe-vpcs document.
+func (i *Importer) ImportVPCs(r io.Reader) error {
+	var doc struct {
+		Vpcs []json.RawMessage `json:"Vpcs"`
+	}
+	if err := decode(r, &doc); err != nil {
+		return err
+	}
+	for _, raw := range doc.Vpcs {
+		var vpc struct {
+			VpcID string `json:"VpcId"`
+		}
+		if err := json.Unmarshal(raw, &vpc); err != nil {
+			return fmt.Errorf("parsing vpc: %w", err)
+		}
+		if _, err := i.insert(vpc.VpcID, vpc.VpcID, LabelVPC, raw, ""); err != nil {
+			return err
+		}
+	}
+	return i.link()
+}
+
+// ImportEC2 reads a describe-instances document, linking each instance to
+// its VPC and attached security groups.
+func (i *Importer) ImportEC2(r io.Reader) error {
+	var doc struct {
+		Reservations []struct {
+			Instances []json.RawMessage `json:"Instances"`
+		} `json:"Reservations"`
+	}
+	if err := decode(r, &doc); err != nil {
+		return err
+	}
+	for _, reservation := range doc.Reservations {
+		for _, raw := range reservation.Instances {
+			var instance struct {
+				InstanceID     string `json:"InstanceId"`
+				VpcID          string `json:"VpcId"`
+				SecurityGroups []struct {
+					GroupID string `json:"GroupId"`
+				} `json:"SecurityGroups"`
+			}
+			if err := json.Unmarshal(raw, &instance); err != nil {
+				return fmt.Errorf("parsing instance: %w", err)
+			}
+			node, err := i.insert(instance.InstanceID, instance.InstanceID, LabelInstance, raw, instance.VpcID)
+			if err != nil {
+				return err
+			}
+			for _, group := range instance.SecurityGroups {
+				i.pendingGroups[node.ID] = append(i.pendingGroups[node.ID], group.GroupID)
+			}
+		}
+	}
+	return i.link()
+}
+
+// ImportSecurityGroups reads a describe-security-groups document.
+func (i *Importer) ImportSecurityGroups(r io.Reader) error {
+	var doc struct {
+		SecurityGroups []json.RawMessage `json:"SecurityGroups"`
+	}
+	if err := decode(r, &doc); err != nil {
+		return err
+	}
+	for _, raw := range doc.SecurityGroups {
+		var group struct {
+			GroupID   string `json:"GroupId"`
+			GroupName string `json:"GroupName"`
+			VpcID     string `json:"VpcId"`
+		}
+		if err := json.Unmarshal(raw, &group); err != nil {
+			return fmt.Errorf("parsing security group: %w", err)
+		}
+		if _, err := i.insert(group.GroupID, group.GroupName, LabelSecurityGroup, raw, group.VpcID); err != nil {
+			return err
+		}
+	}
+	return i.link()
+}
+
+// ImportRDS reads a describe-db-instances document.
+func (i *Importer) ImportRDS(r io.Reader) error {
+	var doc struct {
+		DBInstances []json.RawMessage `json:"DBInstances"`
+	}
+	if err := decode(r, &doc); err != nil {
+		return err
+	}
+	for _, raw := range doc.DBInstances {
+		var db struct {
+			DBInstanceIdentifier string `json:"DBInstanceIdentifier"`
+			DBSubnetGroup        struct {
+				VpcID string `json:"VpcId"`
+			} `json:"DBSubnetGroup"`
+		}
+		if err := json.Unmarshal(raw, &db); err != nil {
+			return fmt.Errorf("parsing db instance: %w", err)
+		}
+		if _, err := i.insert(db.DBInstanceIdentifier, db.DBInstanceIdentifier, LabelRDSInstance, raw, db.DBSubnetGroup.VpcID); err != nil {
+			return err
+		}
+	}
+	return i.link()
+}
+
+// ImportS3 reads a list-buckets document.
+func (i *Importer) ImportS3(r io.Reader) error {
+	var doc struct {
+		Buckets []json.RawMessage `json:"Buckets"`
+	}
+	if err := decode(r, &doc); err != nil {
+		return err
+	}
+	for _, raw := range doc.Buckets {
+		var bucket struct {
+			Name string `json:"Name"`
+		}
+		if err := json.Unmarshal(raw, &bucket); err != nil {
+			return fmt.Errorf("parsing bucket: %w", err)
+		}
+		if _, err := i.insert(bucket.Name, bucket.Name, LabelS3Bucket, raw, ""); err != nil {
+			return err
+		}
+	}
+	return i.link()
+}
+
+// ImportIAMRoles reads a list-roles document and links can-assume edges
+// between roles whose trust policies name each other.
+func (i *Importer) ImportIAMRoles(r io.Reader) error {
+	var doc struct {
+		Roles []json.RawMessage `json:"Roles"`
+	}
+	if err := decode(r, &doc); err != nil {
+		return err
+	}
+	for _, raw := range doc.Roles {
+		var role struct {
+			RoleName                 string `json:"RoleName"`
+			Arn                      string `json:"Arn"`
+			AssumeRolePolicyDocument string `json:"AssumeRolePolicyDocument"`
+		}
+		if err := json.Unmarshal(raw, &role); err != nil {
+			return fmt.Errorf("parsing role: %w", err)
+		}
+		node, err := i.insert(role.Arn, role.RoleName, LabelIAMRole, raw, "")
+		if err != nil {
+			return err
+		}
+		i.pendingTrusts[node.ID] = trustedPrincipals(role.AssumeRolePolicyDocument)
+	}
+	return i.link()
+}
+
+// insert adds one resource node keyed by its AWS identifier, recording the
+// VPC it belongs to for wiring once the VPC is known.
+func (i *Importer) insert(awsID, name, label string, body json.RawMessage, vpcID string) (assets.Node, error) {
+	node, err := i.graph.AddNode(name, label, body)
+	if err != nil {
+		return assets.Node{}, fmt.Errorf("inserting %s %q: %w", label, name, err)
+	}
+	if awsID != "" {
+		i.byAWSID[awsID] = node
+	}
+	if vpcID != "" {
+		i.pendingVPCs[node.ID] = vpcID
+	}
+	return node, nil
+}
+
+// link replays every pending reference against the resources imported so
+// far, wiring the in-vpc, attached-to and can-assume relationships whose
+// endpoints are now known. Entries are kept, so references resolve whichever
+// import call brings in their target.
+func (i *Importer) link() error {
+	for nodeID, vpcID := range i.pendingVPCs {
+		node, err := i.graph.GetNodeByID(nodeID)
+		if err != nil {
+			continue
+		}
+		vpc, ok := i.byAWSID[vpcID]
+		if !ok || i.graph.HasRelationship(node.ID, vpc.ID, RelInVPC) {
+			continue
+		}
+		if _, err := i.graph.AddRelationship(node, vpc, RelInVPC); err != nil {
+			return err
+		}
+	}
+	for nodeID, groupIDs := range i.pendingGroups {
+		node, err := i.graph.GetNodeByID(nodeID)
+		if err != nil {
+			continue
+		}
+		for _, groupID := range groupIDs {
+			groupNode, ok := i.byAWSID[groupID]
+			if !ok || i.graph.HasRelationship(groupNode.ID, node.ID, RelAttachedTo) {
+				continue
+			}
+			if _, err := i.graph.AddRelationship(groupNode, node, RelAttachedTo); err != nil {
+				return err
+			}
+		}
+	}
+	return i.linkTrusts()
+}
+
+// linkTrusts connects every role to the roles its trust policy lets assume
+// it, for the principals imported so far.
+func (i *Importer) linkTrusts() error {
+	for roleNodeID, principals := range i.pendingTrusts {
+		role, err := i.graph.GetNodeByID(roleNodeID)
+		if err != nil {
+			continue
+		}
+		for _, principal := range principals {
+			trusted, ok := i.byAWSID[principal]
+			if !ok || i.graph.HasRelationship(trusted.ID, role.ID, RelCanAssume) {
+				continue
+			}
+			if _, err := i.graph.AddRelationship(trusted, role, RelCanAssume); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// trustedPrincipals extracts the AWS principal ARNs from a trust policy
+// document, which the IAM API returns URL-encoded.
+func trustedPrincipals(document string) []string {
+	decoded, err := url.QueryUnescape(document)
+	if err != nil {
+		decoded = document
+	}
+	var policy struct {
+		Statement []struct {
+			Effect    string `json:"Effect"`
+			Principal struct {
+				AWS json.RawMessage `json:"AWS"`
+			} `json:"Principal"`
+		} `json:"Statement"`
+	}
+	if err := json.Unmarshal([]byte(decoded), &policy); err != nil {
+		return nil
+	}
+	principals := []string{}
+	for _, statement := range policy.Statement {
+		if !strings.EqualFold(statement.Effect, "Allow") || len(statement.Principal.AWS) == 0 {
+			continue
+		}
+		var single string
+		if err := json.Unmarshal(statement.Principal.AWS, &single); err == nil {
+			principals = append(principals, single)
+			continue
+		}
+		var many []string
+		if err := json.Unmarshal(statement.Principal.AWS, &many); err == nil {
+			principals = append(principals, many...)
+		}
+	}
+	return principals
+}
+
+// decode reads and unmarshals one API response document.
+func decode(r io.Reader, into any) error {
+	raw, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("reading document: %w", err)
+	}
+	if err := json.Unmarshal(raw, into); err != nil {
+		return fmt.Errorf("parsing document: %w", err)
+	}
+	return nil
+}
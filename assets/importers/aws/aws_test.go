@@ -0,0 +1,104 @@
+package aws
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mimatache/asset-graph/assets"
+)
+
+const vpcsDoc = `{"Vpcs":[{"VpcId":"vpc-1"}]}`
+
+const groupsDoc = `{"SecurityGroups":[
+	{"GroupId":"sg-1","GroupName":"web","VpcId":"vpc-1"}
+]}`
+
+const instancesDoc = `{"Reservations":[{"Instances":[
+	{"InstanceId":"i-1","VpcId":"vpc-1","SecurityGroups":[{"GroupId":"sg-1"}]}
+]}]}`
+
+func mustNode(t *testing.T, g *assets.Graph, name, label string) assets.Node {
+	t.Helper()
+	node, err := g.GetNodeByNameAndLabel(name, label)
+	if err != nil {
+		t.Fatalf("node %s %q not imported: %v", label, name, err)
+	}
+	return node
+}
+
+func TestImportWiresRelationships(t *testing.T) {
+	g := assets.NewGraph()
+	i := NewImporter(g)
+	if err := i.ImportVPCs(strings.NewReader(vpcsDoc)); err != nil {
+		t.Fatalf("ImportVPCs: %v", err)
+	}
+	if err := i.ImportSecurityGroups(strings.NewReader(groupsDoc)); err != nil {
+		t.Fatalf("ImportSecurityGroups: %v", err)
+	}
+	if err := i.ImportEC2(strings.NewReader(instancesDoc)); err != nil {
+		t.Fatalf("ImportEC2: %v", err)
+	}
+	vpc := mustNode(t, g, "vpc-1", LabelVPC)
+	group := mustNode(t, g, "web", LabelSecurityGroup)
+	instance := mustNode(t, g, "i-1", LabelInstance)
+	if !g.HasRelationship(instance.ID, vpc.ID, RelInVPC) {
+		t.Error("instance not linked to its VPC")
+	}
+	if !g.HasRelationship(group.ID, vpc.ID, RelInVPC) {
+		t.Error("security group not linked to its VPC")
+	}
+	if !g.HasRelationship(group.ID, instance.ID, RelAttachedTo) {
+		t.Error("security group not attached to the instance")
+	}
+}
+
+func TestImportOrderDoesNotMatter(t *testing.T) {
+	g := assets.NewGraph()
+	i := NewImporter(g)
+	// Instances arrive before the VPC and security group they reference;
+	// the pending links must be replayed once the targets appear.
+	if err := i.ImportEC2(strings.NewReader(instancesDoc)); err != nil {
+		t.Fatalf("ImportEC2: %v", err)
+	}
+	if err := i.ImportVPCs(strings.NewReader(vpcsDoc)); err != nil {
+		t.Fatalf("ImportVPCs: %v", err)
+	}
+	if err := i.ImportSecurityGroups(strings.NewReader(groupsDoc)); err != nil {
+		t.Fatalf("ImportSecurityGroups: %v", err)
+	}
+	vpc := mustNode(t, g, "vpc-1", LabelVPC)
+	group := mustNode(t, g, "web", LabelSecurityGroup)
+	instance := mustNode(t, g, "i-1", LabelInstance)
+	if !g.HasRelationship(instance.ID, vpc.ID, RelInVPC) {
+		t.Error("instance imported before its VPC was never linked to it")
+	}
+	if !g.HasRelationship(group.ID, instance.ID, RelAttachedTo) {
+		t.Error("security group imported after the instance was never attached")
+	}
+	// Replaying must not duplicate edges already wired.
+	if rels := g.GetRelationshipsBetween(instance.ID, vpc.ID); len(rels) != 1 {
+		t.Errorf("instance has %d in-vpc edges, want 1", len(rels))
+	}
+}
+
+func TestImportIAMRolesLinksTrusts(t *testing.T) {
+	rolesDoc := `{"Roles":[
+		{"RoleName":"deployer","Arn":"arn:aws:iam::1:role/deployer",
+		 "AssumeRolePolicyDocument":"{\"Statement\":[{\"Effect\":\"Allow\",\"Principal\":{\"AWS\":\"arn:aws:iam::1:role/admin\"}}]}"},
+		{"RoleName":"admin","Arn":"arn:aws:iam::1:role/admin",
+		 "AssumeRolePolicyDocument":"{}"}
+	]}`
+	g := assets.NewGraph()
+	i := NewImporter(g)
+	if err := i.ImportIAMRoles(strings.NewReader(rolesDoc)); err != nil {
+		t.Fatalf("ImportIAMRoles: %v", err)
+	}
+	deployer := mustNode(t, g, "deployer", LabelIAMRole)
+	admin := mustNode(t, g, "admin", LabelIAMRole)
+	if !g.HasRelationship(admin.ID, deployer.ID, RelCanAssume) {
+		t.Error("trusted principal not linked with can-assume")
+	}
+	if g.HasRelationship(deployer.ID, admin.ID, RelCanAssume) {
+		t.Error("can-assume wired in the wrong direction")
+	}
+}
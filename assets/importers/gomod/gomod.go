@@ -0,0 +1,67 @@
+// Package gomod imports Go module dependency graphs into an asset graph, so
+// the package can dog-food its own dependency analysis on Go monorepos. It
+// reads the plain-text edge list printed by `go mod graph`, where every line
+// is "module@version dependency@version".
+package gomod
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/mimatache/asset-graph/assets"
+)
+
+// LabelModule is the label given to every imported module node.
+const LabelModule = "go-module"
+
+// RelRequires is the label of every imported requirement relationship.
+const RelRequires = "requires"
+
+// Import reads `go mod graph` output and inserts one node per module version
+// and one requires relationship per edge. Modules appearing on several lines
+// are inserted once.
+func Import(g *assets.Graph, r io.Reader) error {
+	byName := map[string]assets.Node{}
+	node := func(name string) (assets.Node, error) {
+		if existing, ok := byName[name]; ok {
+			return existing, nil
+		}
+		inserted, err := g.AddNode(name, LabelModule, nil)
+		if err != nil {
+			return assets.Node{}, fmt.Errorf("inserting module %q: %w", name, err)
+		}
+		byName[name] = inserted
+		return inserted, nil
+	}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		parent, child, ok := strings.Cut(line, " ")
+		if !ok {
+			return fmt.Errorf("malformed go mod graph line %q", line)
+		}
+		from, err := node(parent)
+		if err != nil {
+			return err
+		}
+		to, err := node(child)
+		if err != nil {
+			return err
+		}
+		if g.HasRelationship(from.ID, to.ID, RelRequires) {
+			continue
+		}
+		if _, err := g.AddRelationship(from, to, RelRequires); err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("reading go mod graph output: %w", err)
+	}
+	return nil
+}
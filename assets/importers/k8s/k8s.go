@@ -0,0 +1,256 @@
+// Package k8s imports Kubernetes objects into an asset graph. It consumes
+// the standard JSON list form produced by `kubectl get ... -o json` and by
+// client-go's serializers, so it needs no Kubernetes dependency itself: feed
+// it Deployments, Pods, Services, Nodes and Namespaces and it creates the
+// corresponding nodes together with their ownership, namespace, selector and
+// scheduling relationships.
+package k8s
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/mimatache/asset-graph/assets"
+)
+
+// Relationship labels created by the importer.
+const (
+	RelInNamespace = "in_namespace"
+	RelOwns        = "owns"
+	RelSelects     = "selects"
+	RelRunsOn      = "runs_on"
+)
+
+// object is the subset of a Kubernetes manifest the importer reads.
+type object struct {
+	Kind     string `json:"kind"`
+	Metadata struct {
+		Name            string            `json:"name"`
+		Namespace       string            `json:"namespace"`
+		UID             string            `json:"uid"`
+		Labels          map[string]string `json:"labels"`
+		OwnerReferences []struct {
+			Kind string `json:"kind"`
+			Name string `json:"name"`
+			UID  string `json:"uid"`
+		} `json:"ownerReferences"`
+	} `json:"metadata"`
+	Spec struct {
+		NodeName string `json:"nodeName"`
+		Selector struct {
+			MatchLabels map[string]string `json:"matchLabels"`
+		} `json:"selector"`
+		// Services carry their selector directly under spec.selector; the
+		// field is decoded separately because its shape differs per kind.
+	} `json:"spec"`
+}
+
+// list is the envelope kubectl and client-go wrap multiple objects in.
+type list struct {
+	Kind  string            `json:"kind"`
+	Items []json.RawMessage `json:"items"`
+}
+
+// objectLinks retains what an imported object wants to be linked to, so the
+// edges can be wired — or re-attempted — whenever their targets appear.
+type objectLinks struct {
+	nodeID    string
+	namespace string   // metadata.namespace, empty for cluster-scoped objects
+	owners    []string // owner reference UIDs
+	hostNode  string   // spec.nodeName, set for pods
+}
+
+// Importer populates a graph from Kubernetes manifests.
+type Importer struct {
+	graph *assets.Graph
+	// byUID and byKindName resolve owner references and selectors across
+	// successive ImportList calls.
+	byUID      map[string]assets.Node
+	byKindName map[string]assets.Node
+	// links retains every object's wanted edges so namespaces, owners and
+	// nodes imported in any order still get their relationships.
+	links []objectLinks
+	// podLabels and serviceSelectors are retained so services and pods
+	// imported in either order still get their selects edges.
+	podLabels        map[string]map[string]string
+	serviceSelectors map[string]map[string]string
+}
+
+// NewImporter returns an importer inserting into the given graph.
+func NewImporter(g *assets.Graph) *Importer {
+	return &Importer{
+		graph:            g,
+		byUID:            map[string]assets.Node{},
+		byKindName:       map[string]assets.Node{},
+		podLabels:        map[string]map[string]string{},
+		serviceSelectors: map[string]map[string]string{},
+	}
+}
+
+// ImportList reads a Kubernetes List document — the output of
+// `kubectl get pods,services,... -o json` — and imports every item. It can
+// be called repeatedly with further lists; relationships spanning calls are
+// resolved as their endpoints appear.
+func (i *Importer) ImportList(r io.Reader) error {
+	raw, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("reading manifest list: %w", err)
+	}
+	var envelope list
+	if err := json.Unmarshal(raw, &envelope); err != nil {
+		return fmt.Errorf("parsing manifest list: %w", err)
+	}
+	items := envelope.Items
+	if !strings.HasSuffix(envelope.Kind, "List") {
+		// A single object document rather than a list.
+		items = []json.RawMessage{raw}
+	}
+	for _, item := range items {
+		if err := i.importObject(item); err != nil {
+			return err
+		}
+	}
+	if err := i.linkPending(); err != nil {
+		return err
+	}
+	i.linkSelectors()
+	return nil
+}
+
+// importObject inserts one manifest as a node and wires the relationships
+// its metadata declares.
+func (i *Importer) importObject(raw json.RawMessage) error {
+	var obj object
+	if err := json.Unmarshal(raw, &obj); err != nil {
+		return fmt.Errorf("parsing manifest: %w", err)
+	}
+	if obj.Kind == "" || obj.Metadata.Name == "" {
+		return fmt.Errorf("manifest missing kind or metadata.name")
+	}
+	kind := strings.ToLower(obj.Kind)
+	name := obj.Metadata.Name
+	if obj.Metadata.Namespace != "" {
+		name = obj.Metadata.Namespace + "/" + obj.Metadata.Name
+	}
+	node, err := i.graph.AddNode(name, kind, raw)
+	if err != nil {
+		return fmt.Errorf("inserting %s %q: %w", kind, name, err)
+	}
+	if obj.Metadata.UID != "" {
+		i.byUID[obj.Metadata.UID] = node
+	}
+	i.byKindName[kind+"/"+name] = node
+	links := objectLinks{nodeID: node.ID, namespace: obj.Metadata.Namespace}
+	for _, owner := range obj.Metadata.OwnerReferences {
+		links.owners = append(links.owners, owner.UID)
+	}
+	switch kind {
+	case "pod":
+		i.podLabels[name] = obj.Metadata.Labels
+		links.hostNode = obj.Spec.NodeName
+	case "service":
+		selector := map[string]string{}
+		// Service selectors live directly under spec.selector as a flat
+		// map, unlike workload selectors.
+		var svc struct {
+			Spec struct {
+				Selector map[string]string `json:"selector"`
+			} `json:"spec"`
+		}
+		if err := json.Unmarshal(raw, &svc); err == nil {
+			selector = svc.Spec.Selector
+		}
+		if len(selector) > 0 {
+			i.serviceSelectors[name] = selector
+		}
+	}
+	i.links = append(i.links, links)
+	return nil
+}
+
+// linkPending wires the namespace, ownership and scheduling edges of every
+// imported object whose target is now known, skipping pairs already linked.
+// It runs after every ImportList call, so objects can arrive in any order
+// and across calls.
+func (i *Importer) linkPending() error {
+	for _, links := range i.links {
+		node, err := i.graph.GetNodeByID(links.nodeID)
+		if err != nil {
+			continue
+		}
+		if links.namespace != "" {
+			if namespace, ok := i.byKindName["namespace/"+links.namespace]; ok {
+				if !i.graph.HasRelationship(node.ID, namespace.ID, RelInNamespace) {
+					if _, err := i.graph.AddRelationship(node, namespace, RelInNamespace); err != nil {
+						return err
+					}
+				}
+			}
+		}
+		for _, uid := range links.owners {
+			ownerNode, ok := i.byUID[uid]
+			if !ok || i.graph.HasRelationship(ownerNode.ID, node.ID, RelOwns) {
+				continue
+			}
+			if _, err := i.graph.AddRelationship(ownerNode, node, RelOwns); err != nil {
+				return err
+			}
+		}
+		if links.hostNode != "" {
+			if hostNode, ok := i.byKindName["node/"+links.hostNode]; ok {
+				if !i.graph.HasRelationship(node.ID, hostNode.ID, RelRunsOn) {
+					if _, err := i.graph.AddRelationship(node, hostNode, RelRunsOn); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// linkSelectors connects every known service to the pods in its namespace
+// matching its selector, skipping pairs already linked.
+func (i *Importer) linkSelectors() {
+	for serviceName, selector := range i.serviceSelectors {
+		service, ok := i.byKindName["service/"+serviceName]
+		if !ok {
+			continue
+		}
+		for podName, labels := range i.podLabels {
+			if namespaceOf(podName) != namespaceOf(serviceName) || !labelsMatch(selector, labels) {
+				continue
+			}
+			pod, ok := i.byKindName["pod/"+podName]
+			if !ok || i.graph.HasRelationship(service.ID, pod.ID, RelSelects) {
+				continue
+			}
+			i.graph.AddRelationship(service, pod, RelSelects)
+		}
+	}
+}
+
+// namespaceOf extracts the namespace from a "namespace/name" node name.
+// Kubernetes object names cannot contain slashes, so the prefix is reliable.
+func namespaceOf(name string) string {
+	namespace, _, ok := strings.Cut(name, "/")
+	if !ok {
+		return ""
+	}
+	return namespace
+}
+
+// labelsMatch reports whether every selector entry is present in the labels.
+func labelsMatch(selector, labels map[string]string) bool {
+	if len(selector) == 0 {
+		return false
+	}
+	for key, value := range selector {
+		if labels[key] != value {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,83 @@
+package k8s
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mimatache/asset-graph/assets"
+)
+
+const podsDoc = `{"kind":"PodList","items":[
+	{"kind":"Pod","metadata":{"name":"web-1","namespace":"default","uid":"pod-uid-1",
+	 "labels":{"app":"web"},
+	 "ownerReferences":[{"kind":"ReplicaSet","name":"web-rs","uid":"rs-uid-1"}]},
+	 "spec":{"nodeName":"node-1"}}
+]}`
+
+const clusterDoc = `{"kind":"List","items":[
+	{"kind":"Namespace","metadata":{"name":"default","uid":"ns-uid-1"}},
+	{"kind":"Node","metadata":{"name":"node-1","uid":"node-uid-1"}},
+	{"kind":"ReplicaSet","metadata":{"name":"web-rs","namespace":"default","uid":"rs-uid-1"}}
+]}`
+
+func mustNode(t *testing.T, g *assets.Graph, name, label string) assets.Node {
+	t.Helper()
+	node, err := g.GetNodeByNameAndLabel(name, label)
+	if err != nil {
+		t.Fatalf("node %s %q not imported: %v", label, name, err)
+	}
+	return node
+}
+
+func TestImportLinksAcrossCalls(t *testing.T) {
+	g := assets.NewGraph()
+	i := NewImporter(g)
+	// The pod arrives before its namespace, owner and node; the edges must
+	// appear once the later list brings the targets in.
+	if err := i.ImportList(strings.NewReader(podsDoc)); err != nil {
+		t.Fatalf("ImportList(pods): %v", err)
+	}
+	if err := i.ImportList(strings.NewReader(clusterDoc)); err != nil {
+		t.Fatalf("ImportList(cluster): %v", err)
+	}
+	pod := mustNode(t, g, "default/web-1", "pod")
+	namespace := mustNode(t, g, "default", "namespace")
+	host := mustNode(t, g, "node-1", "node")
+	owner := mustNode(t, g, "default/web-rs", "replicaset")
+	if !g.HasRelationship(pod.ID, namespace.ID, RelInNamespace) {
+		t.Error("pod imported before its namespace was never linked to it")
+	}
+	if !g.HasRelationship(owner.ID, pod.ID, RelOwns) {
+		t.Error("owner reference never resolved to an owns edge")
+	}
+	if !g.HasRelationship(pod.ID, host.ID, RelRunsOn) {
+		t.Error("pod never linked to the node it runs on")
+	}
+	// Replaying the retained links must not duplicate edges.
+	if rels := g.GetRelationshipsBetween(pod.ID, namespace.ID); len(rels) != 1 {
+		t.Errorf("pod has %d in_namespace edges, want 1", len(rels))
+	}
+}
+
+func TestServiceSelectorsStayInNamespace(t *testing.T) {
+	doc := `{"kind":"List","items":[
+		{"kind":"Pod","metadata":{"name":"web-1","namespace":"default","uid":"p1","labels":{"app":"web"}}},
+		{"kind":"Pod","metadata":{"name":"web-1","namespace":"staging","uid":"p2","labels":{"app":"web"}}},
+		{"kind":"Service","metadata":{"name":"web","namespace":"default","uid":"s1"},
+		 "spec":{"selector":{"app":"web"}}}
+	]}`
+	g := assets.NewGraph()
+	i := NewImporter(g)
+	if err := i.ImportList(strings.NewReader(doc)); err != nil {
+		t.Fatalf("ImportList: %v", err)
+	}
+	service := mustNode(t, g, "default/web", "service")
+	local := mustNode(t, g, "default/web-1", "pod")
+	foreign := mustNode(t, g, "staging/web-1", "pod")
+	if !g.HasRelationship(service.ID, local.ID, RelSelects) {
+		t.Error("service not linked to the matching pod in its namespace")
+	}
+	if g.HasRelationship(service.ID, foreign.ID, RelSelects) {
+		t.Error("service selected a pod from another namespace")
+	}
+}
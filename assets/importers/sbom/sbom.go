@@ -0,0 +1,148 @@
+// Package sbom imports software bills of materials into an asset graph.
+// CycloneDX and SPDX JSON documents are both understood and detected
+// automatically; components become nodes and declared dependencies become
+// relationships, so questions like "which services contain log4j" reduce to
+// Reachable and ListConnections calls.
+package sbom
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/mimatache/asset-graph/assets"
+)
+
+// LabelComponent is the label given to every imported component node.
+const LabelComponent = "component"
+
+// RelDependsOn is the label of every imported dependency relationship.
+const RelDependsOn = "depends_on"
+
+// Import reads a CycloneDX or SPDX JSON document, detecting which of the two
+// it is, and inserts its components and dependency edges into the graph.
+func Import(g *assets.Graph, r io.Reader) error {
+	raw, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("reading sbom: %w", err)
+	}
+	var sniff struct {
+		BomFormat   string `json:"bomFormat"`
+		SPDXVersion string `json:"spdxVersion"`
+	}
+	if err := json.Unmarshal(raw, &sniff); err != nil {
+		return fmt.Errorf("parsing sbom: %w", err)
+	}
+	switch {
+	case sniff.BomFormat == "CycloneDX":
+		return importCycloneDX(g, raw)
+	case sniff.SPDXVersion != "":
+		return importSPDX(g, raw)
+	default:
+		return fmt.Errorf("unrecognized sbom document: neither CycloneDX nor SPDX")
+	}
+}
+
+// importCycloneDX walks components and the dependency graph of a CycloneDX
+// BOM, keyed by bom-ref.
+func importCycloneDX(g *assets.Graph, raw []byte) error {
+	var bom struct {
+		Components []struct {
+			BomRef  string `json:"bom-ref"`
+			Name    string `json:"name"`
+			Version string `json:"version"`
+		} `json:"components"`
+		Dependencies []struct {
+			Ref       string   `json:"ref"`
+			DependsOn []string `json:"dependsOn"`
+		} `json:"dependencies"`
+	}
+	if err := json.Unmarshal(raw, &bom); err != nil {
+		return fmt.Errorf("parsing CycloneDX bom: %w", err)
+	}
+	byRef := map[string]assets.Node{}
+	for _, component := range bom.Components {
+		body, err := json.Marshal(component)
+		if err != nil {
+			return err
+		}
+		node, err := g.AddNode(componentName(component.Name, component.Version), LabelComponent, body)
+		if err != nil {
+			return fmt.Errorf("inserting component %q: %w", component.Name, err)
+		}
+		if component.BomRef != "" {
+			byRef[component.BomRef] = node
+		}
+	}
+	for _, dependency := range bom.Dependencies {
+		from, ok := byRef[dependency.Ref]
+		if !ok {
+			continue
+		}
+		for _, ref := range dependency.DependsOn {
+			to, ok := byRef[ref]
+			if !ok {
+				continue
+			}
+			if _, err := g.AddRelationship(from, to, RelDependsOn); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// importSPDX walks the packages and DEPENDS_ON relationships of an SPDX
+// document, keyed by SPDX ID.
+func importSPDX(g *assets.Graph, raw []byte) error {
+	var doc struct {
+		Packages []struct {
+			SPDXID      string `json:"SPDXID"`
+			Name        string `json:"name"`
+			VersionInfo string `json:"versionInfo"`
+		} `json:"packages"`
+		Relationships []struct {
+			SPDXElementID      string `json:"spdxElementId"`
+			RelationshipType   string `json:"relationshipType"`
+			RelatedSPDXElement string `json:"relatedSpdxElement"`
+		} `json:"relationships"`
+	}
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return fmt.Errorf("parsing SPDX document: %w", err)
+	}
+	byID := map[string]assets.Node{}
+	for _, pkg := range doc.Packages {
+		body, err := json.Marshal(pkg)
+		if err != nil {
+			return err
+		}
+		node, err := g.AddNode(componentName(pkg.Name, pkg.VersionInfo), LabelComponent, body)
+		if err != nil {
+			return fmt.Errorf("inserting package %q: %w", pkg.Name, err)
+		}
+		byID[pkg.SPDXID] = node
+	}
+	for _, rel := range doc.Relationships {
+		if rel.RelationshipType != "DEPENDS_ON" {
+			continue
+		}
+		from, fromOK := byID[rel.SPDXElementID]
+		to, toOK := byID[rel.RelatedSPDXElement]
+		if !fromOK || !toOK {
+			continue
+		}
+		if _, err := g.AddRelationship(from, to, RelDependsOn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// componentName joins a component's name and version the way package
+// ecosystems usually render them.
+func componentName(name, version string) string {
+	if version == "" {
+		return name
+	}
+	return name + "@" + version
+}
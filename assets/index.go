@@ -0,0 +1,221 @@
+package assets
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// CompareOp names a comparison accepted by FindNodesWhere.
+type CompareOp string
+
+const (
+	OpEq  CompareOp = "=="
+	OpNeq CompareOp = "!="
+	OpGt  CompareOp = ">"
+	OpGte CompareOp = ">="
+	OpLt  CompareOp = "<"
+	OpLte CompareOp = "<="
+)
+
+// indexKey identifies one body-field index.
+type indexKey struct {
+	label string
+	path  string
+}
+
+// bodyIndex holds the extracted value of one JSON path for every node of the
+// indexed label, plus an equality map so OpEq lookups avoid scanning.
+type bodyIndex struct {
+	values  map[string]any             // node ID -> extracted value
+	byValue map[string]map[string]bool // canonical value key -> node IDs
+}
+
+// CreateIndex maintains an index over one JSON field of the bodies of nodes
+// with the given label, e.g.
+//
+//	g.CreateIndex("server", "$.power")
+//
+// The index is built for existing nodes and kept in sync on every insert,
+// update and delete, so FindNodesWhere queries on that label and path stop
+// unmarshalling every body.
+func (g *Graph) CreateIndex(label, jsonPath string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.bodyIndexes == nil {
+		g.bodyIndexes = map[indexKey]*bodyIndex{}
+	}
+	index := &bodyIndex{
+		values:  map[string]any{},
+		byValue: map[string]map[string]bool{},
+	}
+	g.bodyIndexes[indexKey{label: label, path: jsonPath}] = index
+	for id := range g.nodesByLabel[label] {
+		index.add(g.nodes[id], jsonPath)
+	}
+}
+
+// FindNodesWhere returns the nodes of the given label whose body field at
+// jsonPath compares true against value. When CreateIndex has been called for
+// the label and path the lookup uses the index; otherwise it falls back to
+// scanning and unmarshalling bodies.
+func (g *Graph) FindNodesWhere(label, jsonPath string, op CompareOp, value any) ([]Node, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	var matched []Node
+	appendIf := func(id string, candidate any) error {
+		ok, err := compareValues(candidate, op, value)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return nil
+		}
+		if node, exists := g.nodes[id]; exists && g.nodeVisible(node) {
+			matched = append(matched, node)
+		}
+		return nil
+	}
+	if index, ok := g.bodyIndexes[indexKey{label: label, path: jsonPath}]; ok {
+		if op == OpEq {
+			for id := range index.byValue[indexValueKey(value)] {
+				if err := appendIf(id, index.values[id]); err != nil {
+					return nil, err
+				}
+			}
+			return matched, nil
+		}
+		for id, candidate := range index.values {
+			if err := appendIf(id, candidate); err != nil {
+				return nil, err
+			}
+		}
+		return matched, nil
+	}
+	for id := range g.nodesByLabel[label] {
+		candidate, ok := extractBodyPath(g.nodes[id].Body, jsonPath)
+		if !ok {
+			continue
+		}
+		if err := appendIf(id, candidate); err != nil {
+			return nil, err
+		}
+	}
+	return matched, nil
+}
+
+// indexNode records a node in every index covering its label. Callers must
+// hold the write lock.
+func (g *Graph) indexNode(node Node) {
+	for key, index := range g.bodyIndexes {
+		if key.label == node.Label {
+			index.add(node, key.path)
+		}
+	}
+}
+
+// unindexNode removes a node from every index covering its label. Callers
+// must hold the write lock.
+func (g *Graph) unindexNode(node Node) {
+	for key, index := range g.bodyIndexes {
+		if key.label == node.Label {
+			index.remove(node.ID)
+		}
+	}
+}
+
+func (i *bodyIndex) add(node Node, path string) {
+	i.remove(node.ID)
+	value, ok := extractBodyPath(node.Body, path)
+	if !ok {
+		return
+	}
+	i.values[node.ID] = value
+	key := indexValueKey(value)
+	if i.byValue[key] == nil {
+		i.byValue[key] = map[string]bool{}
+	}
+	i.byValue[key][node.ID] = true
+}
+
+func (i *bodyIndex) remove(id string) {
+	value, ok := i.values[id]
+	if !ok {
+		return
+	}
+	delete(i.values, id)
+	key := indexValueKey(value)
+	delete(i.byValue[key], id)
+	if len(i.byValue[key]) == 0 {
+		delete(i.byValue, key)
+	}
+}
+
+// extractBodyPath unmarshals a body and walks a dot path like "$.power" or
+// "specs.cpu.cores" through nested objects.
+func extractBodyPath(body []byte, path string) (any, bool) {
+	if len(body) == 0 {
+		return nil, false
+	}
+	var value any
+	if err := json.Unmarshal(body, &value); err != nil {
+		return nil, false
+	}
+	path = strings.TrimPrefix(path, "$.")
+	for _, step := range strings.Split(path, ".") {
+		object, ok := value.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if value, ok = object[step]; !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+// indexValueKey canonicalizes a value for the equality map so e.g. int 500
+// and the float64 the JSON decoder produces share an entry.
+func indexValueKey(value any) string {
+	if number, ok := asNumber(value); ok {
+		return "n:" + strconv.FormatFloat(number, 'g', -1, 64)
+	}
+	switch v := value.(type) {
+	case string:
+		return "s:" + v
+	case bool:
+		return "b:" + strconv.FormatBool(v)
+	default:
+		return fmt.Sprintf("o:%v", v)
+	}
+}
+
+// compareValues applies op between an extracted body value and the query
+// value. Ordered comparisons require both sides to be numeric.
+func compareValues(candidate any, op CompareOp, value any) (bool, error) {
+	switch op {
+	case OpEq:
+		return indexValueKey(candidate) == indexValueKey(value), nil
+	case OpNeq:
+		return indexValueKey(candidate) != indexValueKey(value), nil
+	case OpGt, OpGte, OpLt, OpLte:
+		left, leftOK := asNumber(candidate)
+		right, rightOK := asNumber(value)
+		if !leftOK || !rightOK {
+			return false, nil
+		}
+		switch op {
+		case OpGt:
+			return left > right, nil
+		case OpGte:
+			return left >= right, nil
+		case OpLt:
+			return left < right, nil
+		default:
+			return left <= right, nil
+		}
+	default:
+		return false, fmt.Errorf("unknown comparison %q", op)
+	}
+}
@@ -0,0 +1,93 @@
+package assets
+
+import "testing"
+
+func TestFindNodesWhereIndexed(t *testing.T) {
+	g := NewGraph()
+	g.CreateIndex("server", "cpu")
+	small, _ := g.AddNode("web-01", "server", []byte(`{"cpu":4}`))
+	big, _ := g.AddNode("web-02", "server", []byte(`{"cpu":16}`))
+	g.AddNode("vpc-1", "network", []byte(`{"cpu":99}`))
+
+	nodes, err := g.FindNodesWhere("server", "cpu", OpEq, float64(4))
+	if err != nil {
+		t.Fatalf("FindNodesWhere eq: %v", err)
+	}
+	if len(nodes) != 1 || nodes[0].ID != small.ID {
+		t.Errorf("eq query returned %+v, want web-01", nodes)
+	}
+	nodes, err = g.FindNodesWhere("server", "cpu", OpGt, float64(4))
+	if err != nil {
+		t.Fatalf("FindNodesWhere gt: %v", err)
+	}
+	if len(nodes) != 1 || nodes[0].ID != big.ID {
+		t.Errorf("gt query returned %+v, want web-02", nodes)
+	}
+}
+
+func TestFindNodesWhereTracksUpdates(t *testing.T) {
+	g := NewGraph()
+	g.CreateIndex("server", "cpu")
+	node, _ := g.AddNode("web-01", "server", []byte(`{"cpu":4}`))
+	if _, err := g.UpdateNode(node.ID, []byte(`{"cpu":8}`)); err != nil {
+		t.Fatalf("UpdateNode: %v", err)
+	}
+	if nodes, _ := g.FindNodesWhere("server", "cpu", OpEq, float64(4)); len(nodes) != 0 {
+		t.Errorf("index still holds the pre-update value: %+v", nodes)
+	}
+	if nodes, _ := g.FindNodesWhere("server", "cpu", OpEq, float64(8)); len(nodes) != 1 {
+		t.Errorf("index missed the updated value")
+	}
+	if err := g.DeleteNode(node.ID); err != nil {
+		t.Fatalf("DeleteNode: %v", err)
+	}
+	if nodes, _ := g.FindNodesWhere("server", "cpu", OpEq, float64(8)); len(nodes) != 0 {
+		t.Errorf("index still holds a deleted node: %+v", nodes)
+	}
+}
+
+func TestFindNodesWhereUnindexedFallback(t *testing.T) {
+	g := NewGraph()
+	g.AddNode("web-01", "server", []byte(`{"region":"eu-west-1"}`))
+	g.AddNode("web-02", "server", []byte(`{"region":"us-east-1"}`))
+	nodes, err := g.FindNodesWhere("server", "region", OpEq, "eu-west-1")
+	if err != nil {
+		t.Fatalf("FindNodesWhere without index: %v", err)
+	}
+	if len(nodes) != 1 || nodes[0].Name != "web-01" {
+		t.Errorf("fallback scan returned %+v, want web-01", nodes)
+	}
+}
+
+func TestSearch(t *testing.T) {
+	g := NewGraph()
+	g.AddNode("webserver-01", "server", []byte(`{"os":"debian"}`))
+	g.AddNode("database-01", "server", []byte(`{"os":"alpine"}`))
+
+	if matches := g.Search("debian"); len(matches) != 1 || matches[0].Name != "webserver-01" {
+		t.Errorf("body search returned %+v, want webserver-01", matches)
+	}
+	if matches := g.Search("webserver"); len(matches) != 1 {
+		t.Errorf("name token search returned %d matches, want 1", len(matches))
+	}
+	if matches := g.Search("web", WithPrefixMatch()); len(matches) != 1 {
+		t.Errorf("prefix search returned %d matches, want 1", len(matches))
+	}
+	if matches := g.Search("datbase", WithFuzzy(1)); len(matches) != 1 {
+		t.Errorf("fuzzy search returned %d matches, want 1", len(matches))
+	}
+	if matches := g.Search("missing"); len(matches) != 0 {
+		t.Errorf("search for absent term returned %+v", matches)
+	}
+}
+
+func TestSearchForgetsDeletedNodes(t *testing.T) {
+	g := NewGraph()
+	node, _ := g.AddNode("webserver-01", "server", nil)
+	if err := g.DeleteNode(node.ID); err != nil {
+		t.Fatalf("DeleteNode: %v", err)
+	}
+	if matches := g.Search("webserver"); len(matches) != 0 {
+		t.Errorf("search found deleted node: %+v", matches)
+	}
+}
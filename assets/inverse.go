@@ -0,0 +1,61 @@
+package assets
+
+// RegisterInverse declares two relationship labels as inverses of each
+// other, e.g.
+//
+//	g.RegisterInverse("owns", "owned_by")
+//
+// From then on, adding a relationship with either label automatically adds
+// the mirrored relationship with the other, and deleting one deletes its
+// mirror, so queries in both directions stay cheap and consistent. The
+// mirrored edge is a full relationship of its own: it shows up in listings,
+// hooks, the WAL and the audit trail.
+func (g *Graph) RegisterInverse(label, inverse string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.inverses == nil {
+		g.inverses = map[string]string{}
+	}
+	g.inverses[label] = inverse
+	g.inverses[inverse] = label
+}
+
+// maintainInverseAdd mirrors a freshly added relationship when its label has
+// a registered inverse. Callers hold the write lock; the guard keeps the
+// mirrored insert from mirroring itself.
+func (g *Graph) maintainInverseAdd(rel Relationship) error {
+	inverse, ok := g.inverses[rel.Label]
+	if !ok || g.maintainingInverse {
+		return nil
+	}
+	from, err := g.getNodeByID(rel.From)
+	if err != nil {
+		return err
+	}
+	to, err := g.getNodeByID(rel.To)
+	if err != nil {
+		return err
+	}
+	g.maintainingInverse = true
+	defer func() { g.maintainingInverse = false }()
+	_, err = g.addRelationship(to, from, inverse, nil)
+	return err
+}
+
+// maintainInverseDelete removes the mirror of a freshly deleted
+// relationship, if one exists.
+func (g *Graph) maintainInverseDelete(rel Relationship) error {
+	inverse, ok := g.inverses[rel.Label]
+	if !ok || g.maintainingInverse {
+		return nil
+	}
+	g.maintainingInverse = true
+	defer func() { g.maintainingInverse = false }()
+	for relID := range g.relsByFrom[rel.To] {
+		candidate := g.relationships[relID]
+		if candidate.To == rel.From && candidate.Label == inverse {
+			return g.deleteRelationship(relID)
+		}
+	}
+	return nil
+}
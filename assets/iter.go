@@ -0,0 +1,115 @@
+package assets
+
+// Seq is a push iterator compatible with the standard library's
+// iter.Seq[V]: calling the sequence with a yield function visits every
+// element until yield returns false. On toolchains with range-over-func the
+// result of NodesIter can be ranged over directly.
+type Seq[V any] func(yield func(V) bool)
+
+// NodesIter streams the nodes matching the filters one at a time. Unlike
+// ListNodes it never materializes the whole node set, and the read lock is
+// released around every yield, so consumers can iterate millions of nodes —
+// or stop early — without stalling writers.
+func (g *Graph) NodesIter(filters ...Filter) Seq[Node] {
+	includeDeleted, filters := splitIncludeDeleted(filters)
+	return func(yield func(Node) bool) {
+		for _, id := range g.nodeIDs() {
+			g.mu.RLock()
+			node, ok := g.nodes[id]
+			visible := ok && (g.nodeVisible(node) || includeDeleted && !node.expiredAt(now()))
+			g.mu.RUnlock()
+			if !visible || !matchesNode(node, filters) {
+				continue
+			}
+			if !yield(node) {
+				return
+			}
+		}
+	}
+}
+
+// RelationshipsIter streams the relationships matching the filters one at a
+// time, with the same locking behaviour as NodesIter.
+func (g *Graph) RelationshipsIter(filters ...FilterRelationship) Seq[Relationship] {
+	return func(yield func(Relationship) bool) {
+		for _, id := range g.relationshipIDs() {
+			g.mu.RLock()
+			rel, ok := g.relationships[id]
+			visible := ok && !rel.expiredAt(now())
+			g.mu.RUnlock()
+			if !visible || !matchesRelationship(rel, filters) {
+				continue
+			}
+			if !yield(rel) {
+				return
+			}
+		}
+	}
+}
+
+// ForEachNode calls fn for every visible node matching the filters, without
+// materializing a slice at all — NodesIter still allocates the ID snapshot,
+// while this walks the node map directly. Returning false from fn stops the
+// walk. The whole walk runs under one read lock acquisition, so fn must be
+// quick and must not call back into the graph.
+func (g *Graph) ForEachNode(fn func(Node) bool, filters ...Filter) {
+	if view := g.stableView(); view != nil {
+		view.ForEachNode(fn, filters...)
+		return
+	}
+	includeDeleted, filters := splitIncludeDeleted(filters)
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	for _, node := range g.nodes {
+		visible := g.nodeVisible(node) || includeDeleted && !node.expiredAt(now())
+		if !visible || !matchesNode(node, filters) {
+			continue
+		}
+		if !fn(node) {
+			return
+		}
+	}
+}
+
+// ForEachRelationship is ForEachNode for relationships, with the same
+// locking behaviour and constraints on fn.
+func (g *Graph) ForEachRelationship(fn func(Relationship) bool, filters ...FilterRelationship) {
+	if view := g.stableView(); view != nil {
+		view.ForEachRelationship(fn, filters...)
+		return
+	}
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	cutoff := now()
+	for _, rel := range g.relationships {
+		if rel.expiredAt(cutoff) || !matchesRelationship(rel, filters) {
+			continue
+		}
+		if !fn(rel) {
+			return
+		}
+	}
+}
+
+// nodeIDs snapshots the current node IDs under the read lock.
+func (g *Graph) nodeIDs() []string {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	ids := make([]string, 0, len(g.nodes))
+	for id := range g.nodes {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// relationshipIDs snapshots the current relationship IDs under the read
+// lock.
+func (g *Graph) relationshipIDs() []string {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	ids := make([]string, 0, len(g.relationships))
+	for id := range g.relationships {
+		ids = append(ids, id)
+	}
+	return ids
+}
@@ -0,0 +1,193 @@
+package assets
+
+import (
+	"container/heap"
+	"sort"
+	"strings"
+)
+
+// KShortestPaths returns up to k distinct paths between the two given nodes,
+// best first, ranked by hop count. It is a practical alternative to
+// ListConnections when only the few most direct routes matter. ErrNotFound
+// is returned when either node is not part of the graph and ErrNoPath when
+// no path exists at all.
+func (g *Graph) KShortestPaths(from, to Node, k int, opts ...TraversalOption) ([]*ChainLink, error) {
+	return g.KShortestPathsWeighted(from, to, k, func(Relationship) float64 { return 1 }, opts...)
+}
+
+// KShortestPathsWeighted is KShortestPaths ranked by the total relationship
+// weight supplied by weightFn, which must not be negative. It runs Yen's
+// algorithm over repeated Dijkstra searches.
+func (g *Graph) KShortestPathsWeighted(from, to Node, k int, weightFn func(Relationship) float64, opts ...TraversalOption) ([]*ChainLink, error) {
+	if view := g.stableView(); view != nil {
+		return view.KShortestPathsWeighted(from, to, k, weightFn, opts...)
+	}
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	if _, err := g.getNodeByID(from.ID); err != nil {
+		return nil, err
+	}
+	if _, err := g.getNodeByID(to.ID); err != nil {
+		return nil, err
+	}
+	if k < 1 {
+		return nil, nil
+	}
+	options := newTraversalOptions(opts)
+	best, cost := g.cheapestPath(from.ID, to.ID, options, weightFn, nil, nil)
+	if best == nil {
+		return nil, ErrNoPath
+	}
+	accepted := [][]string{best}
+	costs := []float64{cost}
+	candidates := map[string]float64{}
+	candidatePaths := map[string][]string{}
+	for len(accepted) < k {
+		previous := accepted[len(accepted)-1]
+		// Branch off every node of the last accepted path, banning the
+		// edges that earlier paths with the same prefix already used.
+		for i := 0; i < len(previous)-1; i++ {
+			spur := previous[i]
+			prefix := previous[:i+1]
+			bannedPairs := map[[2]string]bool{}
+			for _, path := range accepted {
+				if len(path) > i && samePath(path[:i+1], prefix) {
+					bannedPairs[[2]string{path[i], path[i+1]}] = true
+				}
+			}
+			bannedNodes := map[string]bool{}
+			for _, id := range prefix[:len(prefix)-1] {
+				bannedNodes[id] = true
+			}
+			tail, tailCost := g.cheapestPath(spur, to.ID, options, weightFn, bannedPairs, bannedNodes)
+			if tail == nil {
+				continue
+			}
+			candidate := append(append([]string{}, prefix[:len(prefix)-1]...), tail...)
+			key := strings.Join(candidate, "\x00")
+			if _, seen := candidatePaths[key]; seen {
+				continue
+			}
+			candidatePaths[key] = candidate
+			candidates[key] = prefixCost(previous, i, weightFn, g) + tailCost
+		}
+		bestKey := ""
+		for key := range candidates {
+			if bestKey == "" || candidates[key] < candidates[bestKey] ||
+				candidates[key] == candidates[bestKey] && key < bestKey {
+				bestKey = key
+			}
+		}
+		if bestKey == "" {
+			break
+		}
+		accepted = append(accepted, candidatePaths[bestKey])
+		costs = append(costs, candidates[bestKey])
+		delete(candidates, bestKey)
+		delete(candidatePaths, bestKey)
+	}
+	sort.SliceStable(accepted, func(i, j int) bool { return costs[i] < costs[j] })
+	chains := make([]*ChainLink, 0, len(accepted))
+	for _, path := range accepted {
+		chains = append(chains, g.chainFromIDs(path))
+	}
+	return chains, nil
+}
+
+// cheapestPath runs Dijkstra from one node to another, skipping banned nodes
+// and banned (from, to) edge pairs, and returns the node IDs of the best
+// path with its cost, or nil when none exists. Callers must hold at least a
+// read lock.
+func (g *Graph) cheapestPath(fromID, toID string, options traversalOptions, weightFn func(Relationship) float64, bannedPairs map[[2]string]bool, bannedNodes map[string]bool) ([]string, float64) {
+	parents := map[string]string{fromID: ""}
+	distances := map[string]float64{fromID: 0}
+	done := map[string]bool{}
+	queue := &distanceQueue{{id: fromID, distance: 0}}
+	for queue.Len() > 0 {
+		current := heap.Pop(queue).(distanceEntry)
+		if done[current.id] {
+			continue
+		}
+		done[current.id] = true
+		if current.id == toID {
+			path := []string{}
+			for id := toID; id != ""; id = parents[id] {
+				path = append(path, id)
+			}
+			for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+				path[i], path[j] = path[j], path[i]
+			}
+			return path, current.distance
+		}
+		for _, e := range g.edgesFrom(current.id, options) {
+			if done[e.to] || bannedNodes[e.to] || bannedPairs[[2]string{current.id, e.to}] {
+				continue
+			}
+			if _, err := g.getNodeByID(e.to); err != nil {
+				continue
+			}
+			distance := current.distance + weightFn(e.rel)
+			if known, seen := distances[e.to]; seen && known <= distance {
+				continue
+			}
+			distances[e.to] = distance
+			parents[e.to] = current.id
+			heap.Push(queue, distanceEntry{id: e.to, distance: distance})
+		}
+	}
+	return nil, 0
+}
+
+// prefixCost sums the weights along the first steps hops of a path. Callers
+// must hold at least a read lock.
+func prefixCost(path []string, steps int, weightFn func(Relationship) float64, g *Graph) float64 {
+	cost := 0.0
+	for i := 0; i < steps && i < len(path)-1; i++ {
+		cheapest := -1.0
+		for relID := range g.relsByFrom[path[i]] {
+			rel := g.relationships[relID]
+			if rel.To == path[i+1] {
+				if weight := weightFn(rel); cheapest < 0 || weight < cheapest {
+					cheapest = weight
+				}
+			}
+		}
+		if cheapest > 0 {
+			cost += cheapest
+		}
+	}
+	return cost
+}
+
+// chainFromIDs turns a list of node IDs into a linear chain of links.
+// Callers must hold at least a read lock.
+func (g *Graph) chainFromIDs(ids []string) *ChainLink {
+	var chain *ChainLink
+	for i := len(ids) - 1; i >= 0; i-- {
+		node, err := g.getNodeByID(ids[i])
+		if err != nil {
+			return nil
+		}
+		link := &ChainLink{node: node}
+		if i > 0 {
+			link.rel, _ = g.connectingRel(ids[i-1], ids[i])
+		}
+		if chain != nil {
+			link.next = []*ChainLink{chain}
+		}
+		chain = link
+	}
+	return chain
+}
+
+func samePath(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,200 @@
+package assets
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"sync"
+)
+
+// Loader is a write path built for write-heavy imports feeding the graph
+// from many goroutines at once. The graph's single write lock keeps every
+// invariant — indexes, WAL ordering, transactions, hooks — simple, but it
+// also makes concurrent inserts serialize on one mutex. The loader shards
+// that contention point: callers append into per-shard buffers guarded by
+// per-shard locks, so discovery workers scale with cores, and a drain pass
+// applies whole batches to the graph under a single lock acquisition.
+//
+// Writes become visible when a shard fills or when Flush is called; readers
+// of the graph itself are never blocked by buffered work.
+type Loader struct {
+	graph  *Graph
+	shards []loaderShard
+
+	errMu sync.Mutex
+	errs  []error
+}
+
+// loaderShard buffers pending inserts behind its own lock.
+type loaderShard struct {
+	mu    sync.Mutex
+	nodes []pendingNode
+	rels  []pendingRel
+}
+
+type pendingNode struct {
+	name  string
+	label string
+	body  []byte
+	opts  []NodeOption
+}
+
+// pendingRel names its endpoints, since the node IDs may not exist until
+// the batch containing them drains.
+type pendingRel struct {
+	fromName  string
+	fromLabel string
+	toName    string
+	toLabel   string
+	label     string
+	opts      []RelOption
+}
+
+// loaderBatchSize is how many buffered entries a shard accumulates before it
+// is drained into the graph.
+const loaderBatchSize = 512
+
+// NewLoader returns a loader with the given number of shards writing into
+// this graph. A shard count around the number of importing goroutines keeps
+// them from contending with each other.
+func (g *Graph) NewLoader(shards int) *Loader {
+	if shards < 1 {
+		shards = 1
+	}
+	return &Loader{
+		graph:  g,
+		shards: make([]loaderShard, shards),
+	}
+}
+
+// AddNode buffers a node insert. The node becomes visible in the graph at
+// the next drain; insert errors are reported by Flush.
+func (l *Loader) AddNode(name, label string, body []byte, opts ...NodeOption) {
+	shard := l.shard(name)
+	shard.mu.Lock()
+	shard.nodes = append(shard.nodes, pendingNode{name: name, label: label, body: body, opts: opts})
+	full := len(shard.nodes) >= loaderBatchSize
+	shard.mu.Unlock()
+	if full {
+		l.drain(shard)
+	}
+}
+
+// AddRelationship buffers a relationship insert between nodes identified by
+// name and label, which may themselves still be buffered.
+func (l *Loader) AddRelationship(fromName, fromLabel, toName, toLabel, label string, opts ...RelOption) {
+	shard := l.shard(fromName)
+	shard.mu.Lock()
+	shard.rels = append(shard.rels, pendingRel{
+		fromName: fromName, fromLabel: fromLabel,
+		toName: toName, toLabel: toLabel,
+		label: label, opts: opts,
+	})
+	full := len(shard.rels) >= loaderBatchSize
+	shard.mu.Unlock()
+	if full {
+		l.drain(shard)
+	}
+}
+
+// Flush drains every shard — nodes first, so buffered relationships can
+// resolve their endpoints — and returns the accumulated insert errors.
+// Callers must Flush once their workers are done; until then some writes
+// may still be buffered.
+func (l *Loader) Flush() error {
+	for i := range l.shards {
+		l.drainNodes(&l.shards[i])
+	}
+	for i := range l.shards {
+		l.drainRels(&l.shards[i], true)
+	}
+	l.errMu.Lock()
+	defer l.errMu.Unlock()
+	err := errors.Join(l.errs...)
+	l.errs = nil
+	return err
+}
+
+func (l *Loader) shard(key string) *loaderShard {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return &l.shards[h.Sum32()%uint32(len(l.shards))]
+}
+
+// drain empties a shard that filled up. Nodes are drained from every shard
+// first, because this shard's relationships may name endpoints buffered in
+// other shards; endpoints that still cannot be resolved — their nodes are
+// yet to be buffered — are requeued rather than failed, and only Flush
+// treats them as errors.
+func (l *Loader) drain(shard *loaderShard) {
+	for i := range l.shards {
+		l.drainNodes(&l.shards[i])
+	}
+	l.drainRels(shard, false)
+}
+
+// drainNodes applies a shard's buffered node inserts in one lock
+// acquisition.
+func (l *Loader) drainNodes(shard *loaderShard) {
+	shard.mu.Lock()
+	nodes := shard.nodes
+	shard.nodes = nil
+	shard.mu.Unlock()
+	if len(nodes) == 0 {
+		return
+	}
+	l.graph.mu.Lock()
+	for _, pending := range nodes {
+		if _, err := l.graph.addNode(pending.name, pending.label, pending.body, pending.opts); err != nil {
+			l.record(err)
+		}
+	}
+	l.graph.mu.Unlock()
+}
+
+// drainRels applies a shard's buffered relationship inserts, resolving
+// endpoints by name and label. Unless final, inserts whose endpoints do not
+// exist yet are requeued for a later drain instead of failed.
+func (l *Loader) drainRels(shard *loaderShard, final bool) {
+	shard.mu.Lock()
+	rels := shard.rels
+	shard.rels = nil
+	shard.mu.Unlock()
+	if len(rels) == 0 {
+		return
+	}
+	var requeue []pendingRel
+	l.graph.mu.Lock()
+	for _, pending := range rels {
+		from, fromErr := l.graph.lookupByNameAndLabel(pending.fromName, pending.fromLabel)
+		to, toErr := l.graph.lookupByNameAndLabel(pending.toName, pending.toLabel)
+		if fromErr != nil || toErr != nil {
+			if !final {
+				requeue = append(requeue, pending)
+				continue
+			}
+			if fromErr != nil {
+				l.record(fmt.Errorf("relationship endpoint %s %q: %w", pending.fromLabel, pending.fromName, fromErr))
+			}
+			if toErr != nil {
+				l.record(fmt.Errorf("relationship endpoint %s %q: %w", pending.toLabel, pending.toName, toErr))
+			}
+			continue
+		}
+		if _, err := l.graph.addRelationship(from, to, pending.label, pending.opts); err != nil {
+			l.record(err)
+		}
+	}
+	l.graph.mu.Unlock()
+	if len(requeue) > 0 {
+		shard.mu.Lock()
+		shard.rels = append(shard.rels, requeue...)
+		shard.mu.Unlock()
+	}
+}
+
+func (l *Loader) record(err error) {
+	l.errMu.Lock()
+	l.errs = append(l.errs, err)
+	l.errMu.Unlock()
+}
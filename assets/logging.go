@@ -0,0 +1,24 @@
+package assets
+
+import (
+	"context"
+	"log/slog"
+)
+
+// WithLogger makes the graph log through the given slog handler: mutations
+// at debug level, and rejected mutations — constraint violations, schema
+// failures — at warn level. Without it the graph stays silent.
+func WithLogger(handler slog.Handler) GraphOption {
+	return func(g *Graph) {
+		g.logger = slog.New(handler)
+	}
+}
+
+// logEvent emits a log record when a logger is configured. Safe to call with
+// any lock held; the handler must not call back into the graph.
+func (g *Graph) logEvent(level slog.Level, msg string, args ...any) {
+	if g.logger == nil {
+		return
+	}
+	g.logger.Log(context.Background(), level, msg, args...)
+}
@@ -0,0 +1,100 @@
+package assets
+
+import "unsafe"
+
+// MemoryStats estimates where a graph's memory goes, so operators of
+// long-running processes can see which asset types dominate. Figures are
+// estimates: struct sizes and string and body lengths are counted exactly,
+// while per-entry map overhead is approximated with a flat constant.
+type MemoryStats struct {
+	NodeBytes         int64 `json:"node_bytes"`
+	BodyBytes         int64 `json:"body_bytes"`
+	RelationshipBytes int64 `json:"relationship_bytes"`
+	IndexBytes        int64 `json:"index_bytes"`
+	TotalBytes        int64 `json:"total_bytes"`
+	// PerLabel breaks the node and body figures down by node label and the
+	// relationship figure down by relationship label.
+	PerLabel map[string]LabelMemory `json:"per_label"`
+}
+
+// LabelMemory is the per-label slice of a MemoryStats breakdown.
+type LabelMemory struct {
+	Nodes             int   `json:"nodes,omitempty"`
+	NodeBytes         int64 `json:"node_bytes,omitempty"`
+	BodyBytes         int64 `json:"body_bytes,omitempty"`
+	Relationships     int   `json:"relationships,omitempty"`
+	RelationshipBytes int64 `json:"relationship_bytes,omitempty"`
+}
+
+// mapEntryOverhead approximates the bookkeeping bytes a map spends per
+// entry, on top of key and value.
+const mapEntryOverhead = 48
+
+// MemoryStats estimates the bytes held by nodes, bodies, relationships and
+// the secondary indexes, broken down by label.
+func (g *Graph) MemoryStats() MemoryStats {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	stats := MemoryStats{PerLabel: map[string]LabelMemory{}}
+	for _, node := range g.nodes {
+		size := int64(unsafe.Sizeof(node)) + mapEntryOverhead +
+			int64(len(node.ID)+len(node.Name)+len(node.Label)+len(node.CreatedBy)+len(node.UpdatedBy))
+		for key, value := range node.Properties {
+			size += int64(len(key)) + mapEntryOverhead
+			if s, ok := value.(string); ok {
+				size += int64(len(s))
+			}
+		}
+		body := int64(len(node.Body))
+		stats.NodeBytes += size
+		stats.BodyBytes += body
+		entry := stats.PerLabel[node.Label]
+		entry.Nodes++
+		entry.NodeBytes += size
+		entry.BodyBytes += body
+		stats.PerLabel[node.Label] = entry
+	}
+	for _, rel := range g.relationships {
+		size := int64(unsafe.Sizeof(rel)) + mapEntryOverhead +
+			int64(len(rel.ID)+len(rel.From)+len(rel.To)+len(rel.Label)+len(rel.CreatedBy)+len(rel.UpdatedBy)+len(rel.Body))
+		stats.RelationshipBytes += size
+		entry := stats.PerLabel[rel.Label]
+		entry.Relationships++
+		entry.RelationshipBytes += size
+		stats.PerLabel[rel.Label] = entry
+	}
+	stats.IndexBytes += indexSize(g.nodesByLabel)
+	stats.IndexBytes += indexSize(g.nodesByName)
+	stats.IndexBytes += indexSize(g.relsByFrom)
+	stats.IndexBytes += indexSize(g.relsByTo)
+	for key, index := range g.bodyIndexes {
+		stats.IndexBytes += int64(len(key.label)+len(key.path)) + mapEntryOverhead
+		for id := range index.values {
+			stats.IndexBytes += int64(len(id)) + mapEntryOverhead
+		}
+		stats.IndexBytes += indexSize(index.byValue)
+	}
+	if g.search != nil {
+		stats.IndexBytes += indexSize(g.search.byToken)
+		for id, tokens := range g.search.tokens {
+			stats.IndexBytes += int64(len(id)) + mapEntryOverhead
+			for _, token := range tokens {
+				stats.IndexBytes += int64(len(token)) + int64(unsafe.Sizeof(token))
+			}
+		}
+	}
+	stats.TotalBytes = stats.NodeBytes + stats.BodyBytes + stats.RelationshipBytes + stats.IndexBytes
+	return stats
+}
+
+// indexSize estimates one of the two-level ID indexes.
+func indexSize(index map[string]map[string]bool) int64 {
+	size := int64(0)
+	for key, ids := range index {
+		size += int64(len(key)) + mapEntryOverhead
+		for id := range ids {
+			size += int64(len(id)) + 1 + mapEntryOverhead
+		}
+	}
+	return size
+}
@@ -0,0 +1,113 @@
+package assets
+
+// MergeStrategy decides which version of a node wins when both graphs
+// contain a node with the same name and label. It receives our node and
+// their node and returns the state to keep; the ID of our node is always
+// preserved.
+type MergeStrategy func(ours, theirs Node) Node
+
+// OursWins keeps the receiving graph's version of conflicting nodes.
+func OursWins(ours, _ Node) Node { return ours }
+
+// TheirsWins takes the incoming graph's version of conflicting nodes.
+func TheirsWins(_, theirs Node) Node { return theirs }
+
+// MergeReport summarizes what a merge changed.
+type MergeReport struct {
+	NodesAdded         int `json:"nodes_added"`
+	NodesMerged        int `json:"nodes_merged"`
+	RelationshipsAdded int `json:"relationships_added"`
+}
+
+// Merge combines another graph into this one. Nodes are matched by name and
+// label: unmatched nodes are copied over, and matched nodes are resolved
+// with the given strategy, keeping this graph's node ID so existing
+// references stay valid. Relationships are rewritten onto the surviving
+// node IDs and copied unless an identical edge (same endpoints and label)
+// already exists. The other graph must not be mutated while the merge runs.
+func (g *Graph) Merge(other *Graph, strategy MergeStrategy) (MergeReport, error) {
+	if strategy == nil {
+		strategy = OursWins
+	}
+	report := MergeReport{}
+	otherNodes := other.ListNodes()
+	otherRels := other.ListRelationships()
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	// idMap rewrites the other graph's node IDs onto the IDs surviving in
+	// this graph.
+	idMap := map[string]string{}
+	for _, theirs := range otherNodes {
+		ours, err := g.lookupByNameAndLabel(theirs.Name, theirs.Label)
+		if err != nil {
+			copied := theirs
+			if _, taken := g.nodes[copied.ID]; taken {
+				copied.ID = newID()
+			}
+			g.putNode(copied)
+			if err := g.logMutation(walEntry{Op: opInsertNode, Node: &copied}); err != nil {
+				return report, err
+			}
+			idMap[theirs.ID] = copied.ID
+			report.NodesAdded++
+			continue
+		}
+		idMap[theirs.ID] = ours.ID
+		merged := strategy(ours, theirs)
+		merged.ID = ours.ID
+		if merged.Version == ours.Version && string(merged.Body) == string(ours.Body) {
+			continue
+		}
+		merged.Version = ours.Version + 1
+		merged.UpdatedAt = now()
+		g.putNode(merged)
+		if err := g.logMutation(walEntry{Op: opUpdateNode, Node: &merged}); err != nil {
+			return report, err
+		}
+		report.NodesMerged++
+	}
+	for _, rel := range otherRels {
+		from, okFrom := idMap[rel.From]
+		to, okTo := idMap[rel.To]
+		if !okFrom || !okTo {
+			continue
+		}
+		if g.hasRelationshipBetween(from, to, rel.Label) {
+			continue
+		}
+		copied := rel
+		copied.From = from
+		copied.To = to
+		if _, taken := g.relationships[copied.ID]; taken {
+			copied.ID = newID()
+		}
+		g.putRelationship(copied)
+		if err := g.logMutation(walEntry{Op: opAddRelationship, Relationship: &copied}); err != nil {
+			return report, err
+		}
+		report.RelationshipsAdded++
+	}
+	return report, nil
+}
+
+// lookupByNameAndLabel is the lock-free variant of GetNodeByNameAndLabel.
+func (g *Graph) lookupByNameAndLabel(name, label string) (Node, error) {
+	for id := range g.nodesByName[name] {
+		if node := g.nodes[id]; node.Label == label && g.nodeVisible(node) {
+			return node, nil
+		}
+	}
+	return Node{}, ErrNotFound
+}
+
+// hasRelationshipBetween reports whether an edge with the given label
+// already connects the two nodes. Callers must hold at least a read lock.
+func (g *Graph) hasRelationshipBetween(from, to, label string) bool {
+	for relID := range g.relsByFrom[from] {
+		rel := g.relationships[relID]
+		if rel.To == to && rel.Label == label {
+			return true
+		}
+	}
+	return false
+}
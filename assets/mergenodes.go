@@ -0,0 +1,77 @@
+package assets
+
+// MergeNodes collapses two nodes that describe the same asset — the usual
+// aftermath of two scanners reporting one machine under slightly different
+// names. Every relationship of the dropped node is re-pointed at the kept
+// node, relationships running between the two are discarded rather than
+// turned into self-loops, and the dropped node is then deleted. When
+// bodyMerge is non-nil it receives the kept and dropped bodies and its
+// result replaces the kept node's body. The merged node is returned.
+func (g *Graph) MergeNodes(keepID, dropID string, bodyMerge func(keep, drop []byte) []byte) (Node, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	keep, ok := g.nodes[keepID]
+	if !ok || keep.isDeleted() {
+		return Node{}, ErrNotFound
+	}
+	drop, ok := g.nodes[dropID]
+	if !ok || drop.isDeleted() {
+		return Node{}, ErrNotFound
+	}
+	if keepID == dropID {
+		return keep, nil
+	}
+	for _, relID := range relationshipIDsOf(g.relsByFrom[dropID], g.relsByTo[dropID]) {
+		rel := g.relationships[relID]
+		if (rel.From == keepID || rel.From == dropID) && (rel.To == keepID || rel.To == dropID) {
+			if err := g.deleteRelationship(relID); err != nil {
+				return Node{}, err
+			}
+			continue
+		}
+		g.removeRelationship(rel)
+		if rel.From == dropID {
+			rel.From = keepID
+		}
+		if rel.To == dropID {
+			rel.To = keepID
+		}
+		rel.UpdatedAt = now()
+		if g.actor != "" {
+			rel.UpdatedBy = g.actor
+		}
+		g.putRelationship(rel)
+		if err := g.logMutation(walEntry{Op: opUpdateRelationship, Relationship: &rel}); err != nil {
+			return Node{}, err
+		}
+		updated := rel
+		g.emitAfter(func() { runAfter(g.hooks.afterUpdateRel, updated) })
+	}
+	if bodyMerge != nil {
+		merged, err := g.updateNode(keepID, bodyMerge(keep.Body, drop.Body))
+		if err != nil {
+			return Node{}, err
+		}
+		keep = merged
+	}
+	if err := g.deleteNode(dropID, nil); err != nil {
+		return Node{}, err
+	}
+	return keep, nil
+}
+
+// relationshipIDsOf collects the IDs from adjacency sets into a stable
+// slice, so relationships can be mutated while iterating.
+func relationshipIDsOf(sets ...map[string]bool) []string {
+	ids := []string{}
+	seen := map[string]bool{}
+	for _, set := range sets {
+		for id := range set {
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+	return ids
+}
@@ -0,0 +1,42 @@
+package assets
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ToMermaid renders the graph as a Mermaid "graph TD" diagram suitable for
+// embedding in Markdown. Nodes are shown with their name and label and edges
+// with their relationship label. When filters are given, only matching nodes
+// and the relationships between them are rendered.
+func (g *Graph) ToMermaid(filters ...Filter) string {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	included := map[string]bool{}
+	var b strings.Builder
+	b.WriteString("graph TD\n")
+	for _, node := range sortedNodes(g.nodes) {
+		if !matchesNode(node, filters) {
+			continue
+		}
+		included[node.ID] = true
+		fmt.Fprintf(&b, "  %s[\"%s (%s)\"]\n", node.ID, mermaidEscape(node.Name), mermaidEscape(node.Label))
+	}
+	for _, rel := range sortedRelationships(g.relationships) {
+		if !included[rel.From] || !included[rel.To] {
+			continue
+		}
+		if rel.Label == "" {
+			fmt.Fprintf(&b, "  %s --> %s\n", rel.From, rel.To)
+			continue
+		}
+		fmt.Fprintf(&b, "  %s -->|%s| %s\n", rel.From, mermaidEscape(rel.Label), rel.To)
+	}
+	return b.String()
+}
+
+// mermaidEscape replaces the characters that terminate Mermaid node and edge
+// text so names cannot break out of their quoting.
+func mermaidEscape(s string) string {
+	return strings.NewReplacer("\"", "#quot;", "|", "/", "\n", " ").Replace(s)
+}
@@ -0,0 +1,55 @@
+package assets
+
+// SetName names the graph, e.g. "prod" or "staging". The name is carried
+// through Save and Load so snapshots of different environments can be told
+// apart after the fact.
+func (g *Graph) SetName(name string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.name = name
+}
+
+// Name returns the graph's name.
+func (g *Graph) Name() string {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.name
+}
+
+// SetDescription describes the graph in free text, carried through Save and
+// Load like the name.
+func (g *Graph) SetDescription(description string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.description = description
+}
+
+// Description returns the graph's description.
+func (g *Graph) Description() string {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.description
+}
+
+// SetMeta attaches an arbitrary key/value annotation to the graph itself —
+// the discovery run, the region, the scanner version — persisted alongside
+// the nodes by Save.
+func (g *Graph) SetMeta(key, value string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.meta == nil {
+		g.meta = map[string]string{}
+	}
+	g.meta[key] = value
+}
+
+// Meta returns a copy of the graph's annotations.
+func (g *Graph) Meta() map[string]string {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	meta := make(map[string]string, len(g.meta))
+	for key, value := range g.meta {
+		meta[key] = value
+	}
+	return meta
+}
@@ -0,0 +1,48 @@
+package assets
+
+// WithMVCCReads makes the heavy read operations — ListNodes, ListConnections
+// and the path searches — run against an internally maintained copy-on-write
+// snapshot instead of holding the graph's read lock for their whole
+// duration. A long path search then no longer stalls mutations; it simply
+// observes the graph as it stood when the search began. The snapshot is
+// rebuilt lazily on the first heavy read after a mutation, so read-mostly
+// workloads pay for at most one rebuild per write burst.
+func WithMVCCReads() GraphOption {
+	return func(g *Graph) {
+		g.mvccEnabled = true
+	}
+}
+
+// bumpEpoch marks the current snapshot stale. Callers must hold the write
+// lock; every mutation path funnels through the put/remove helpers or
+// restoreState, which call it.
+func (g *Graph) bumpEpoch() {
+	g.epoch++
+}
+
+// stableView returns the cached immutable view of the graph, rebuilding it
+// if the graph has changed since it was taken, or nil when MVCC reads are
+// not enabled. The view is a bare graph sharing node and relationship
+// values with the live one; values are safe to share because mutations
+// replace map entries rather than editing them in place.
+func (g *Graph) stableView() *Graph {
+	if !g.mvccEnabled {
+		return nil
+	}
+	g.viewMu.Lock()
+	defer g.viewMu.Unlock()
+	g.mu.RLock()
+	epoch := g.epoch
+	if g.view != nil && g.viewEpoch == epoch {
+		g.mu.RUnlock()
+		return g.view
+	}
+	state := g.copyState()
+	g.mu.RUnlock()
+	view := NewGraph()
+	view.restoreState(state)
+	view.softDelete = g.softDelete
+	g.view = view
+	g.viewEpoch = epoch
+	return view
+}
@@ -0,0 +1,37 @@
+package assets
+
+import "time"
+
+// Node represents a single asset tracked by the graph. Nodes are identified
+// by an ID generated when they are added; the Name and Label describe the
+// asset and the optional Body carries an arbitrary payload.
+type Node struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Label string `json:"label"`
+	Body  []byte `json:"body,omitempty"`
+	// Properties carries structured key/value attributes that can be
+	// filtered on directly, without unmarshalling Body. See
+	// FilterNodesByProperty.
+	Properties map[string]any `json:"properties,omitempty"`
+	// Version increases by one on every update to the node, enabling
+	// optimistic concurrency via UpdateNodeIfVersion.
+	Version uint64 `json:"version,omitempty"`
+	// CreatedAt and UpdatedAt are maintained automatically on insert and
+	// update so asset freshness can be queried.
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+	// CreatedBy and UpdatedBy attribute the node to the actor that asserted
+	// it, e.g. the discovery source. See AsActor and Graph.SetActor.
+	CreatedBy string `json:"created_by,omitempty"`
+	UpdatedBy string `json:"updated_by,omitempty"`
+	// DeletedAt is the soft-delete tombstone set by DeleteNode on graphs
+	// created with WithSoftDelete; see RestoreNode.
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+	// ExpiresAt is the optional TTL deadline set with WithTTL; expired
+	// nodes are hidden from reads and removed by the reaper.
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	// Provenance records which system observed the asset, when, and with
+	// what confidence. See ObserveNode.
+	Provenance *Provenance `json:"provenance,omitempty"`
+}
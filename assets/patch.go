@@ -0,0 +1,307 @@
+package assets
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// PatchType selects which patch format PatchNode applies.
+type PatchType int
+
+const (
+	// JSONPatch is an RFC 6902 operation list, e.g.
+	// [{"op":"replace","path":"/power","value":12}].
+	JSONPatch PatchType = iota
+	// MergePatch is an RFC 7386 merge document: objects merge recursively
+	// and null values delete keys.
+	MergePatch
+)
+
+// PatchNode applies a JSON Patch or Merge Patch to the body of the node with
+// the given ID, so a single field can be changed without racing a full
+// read-modify-write of the body. The patched body goes through the same
+// update path as UpdateNode, including schema validation, hooks and history.
+// A malformed patch or a failing JSON Patch test wraps ErrValidation.
+func (g *Graph) PatchNode(id string, patch []byte, patchType PatchType) (Node, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	node, ok := g.nodes[id]
+	if !ok || node.isDeleted() {
+		return Node{}, ErrNotFound
+	}
+	body := node.Body
+	if len(body) == 0 {
+		body = []byte("null")
+	}
+	var patched []byte
+	var err error
+	switch patchType {
+	case JSONPatch:
+		patched, err = applyJSONPatch(body, patch)
+	case MergePatch:
+		patched, err = applyMergePatch(body, patch)
+	default:
+		err = fmt.Errorf("%w: unknown patch type %d", ErrValidation, patchType)
+	}
+	if err != nil {
+		return Node{}, err
+	}
+	return g.updateNode(id, patched)
+}
+
+// applyMergePatch implements RFC 7386 over raw JSON documents.
+func applyMergePatch(body, patch []byte) ([]byte, error) {
+	var patchValue interface{}
+	if err := json.Unmarshal(patch, &patchValue); err != nil {
+		return nil, fmt.Errorf("%w: parsing merge patch: %v", ErrValidation, err)
+	}
+	patchObject, ok := patchValue.(map[string]interface{})
+	if !ok {
+		// A non-object patch replaces the whole body.
+		return json.Marshal(patchValue)
+	}
+	var bodyValue interface{}
+	if err := json.Unmarshal(body, &bodyValue); err != nil {
+		return nil, fmt.Errorf("%w: parsing body: %v", ErrValidation, err)
+	}
+	return json.Marshal(mergeValues(bodyValue, patchObject))
+}
+
+// mergeValues merges one level of a merge patch into a body value.
+func mergeValues(body interface{}, patch map[string]interface{}) interface{} {
+	bodyObject, ok := body.(map[string]interface{})
+	if !ok {
+		bodyObject = map[string]interface{}{}
+	}
+	for key, value := range patch {
+		if value == nil {
+			delete(bodyObject, key)
+			continue
+		}
+		if nested, ok := value.(map[string]interface{}); ok {
+			bodyObject[key] = mergeValues(bodyObject[key], nested)
+			continue
+		}
+		bodyObject[key] = value
+	}
+	return bodyObject
+}
+
+// jsonPatchOp is one RFC 6902 operation.
+type jsonPatchOp struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from"`
+	Value json.RawMessage `json:"value"`
+}
+
+// applyJSONPatch implements RFC 6902 over raw JSON documents.
+func applyJSONPatch(body, patch []byte) ([]byte, error) {
+	var ops []jsonPatchOp
+	if err := json.Unmarshal(patch, &ops); err != nil {
+		return nil, fmt.Errorf("%w: parsing json patch: %v", ErrValidation, err)
+	}
+	var document interface{}
+	if err := json.Unmarshal(body, &document); err != nil {
+		return nil, fmt.Errorf("%w: parsing body: %v", ErrValidation, err)
+	}
+	for i, op := range ops {
+		var err error
+		switch op.Op {
+		case "add":
+			document, err = pointerSet(document, op.Path, rawValue(op.Value), true)
+		case "replace":
+			document, err = pointerSet(document, op.Path, rawValue(op.Value), false)
+		case "remove":
+			document, err = pointerRemove(document, op.Path)
+		case "move":
+			var moved interface{}
+			moved, err = pointerGet(document, op.From)
+			if err == nil {
+				document, err = pointerRemove(document, op.From)
+			}
+			if err == nil {
+				document, err = pointerSet(document, op.Path, moved, true)
+			}
+		case "copy":
+			var copied interface{}
+			copied, err = pointerGet(document, op.From)
+			if err == nil {
+				document, err = pointerSet(document, op.Path, copied, true)
+			}
+		case "test":
+			var current interface{}
+			current, err = pointerGet(document, op.Path)
+			if err == nil && !reflect.DeepEqual(current, rawValue(op.Value)) {
+				err = fmt.Errorf("test at %q failed", op.Path)
+			}
+		default:
+			err = fmt.Errorf("unknown op %q", op.Op)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: json patch op %d: %v", ErrValidation, i, err)
+		}
+	}
+	return json.Marshal(document)
+}
+
+// rawValue decodes an operation value the same way the document itself was
+// decoded, so test comparisons use matching representations.
+func rawValue(raw json.RawMessage) interface{} {
+	var value interface{}
+	json.Unmarshal(raw, &value)
+	return value
+}
+
+// pointerTokens splits an RFC 6901 JSON Pointer into its unescaped tokens.
+func pointerTokens(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("invalid pointer %q", pointer)
+	}
+	parts := strings.Split(pointer[1:], "/")
+	for i, part := range parts {
+		parts[i] = strings.ReplaceAll(strings.ReplaceAll(part, "~1", "/"), "~0", "~")
+	}
+	return parts, nil
+}
+
+// pointerGet resolves a pointer against the document.
+func pointerGet(document interface{}, pointer string) (interface{}, error) {
+	tokens, err := pointerTokens(pointer)
+	if err != nil {
+		return nil, err
+	}
+	current := document
+	for _, token := range tokens {
+		switch value := current.(type) {
+		case map[string]interface{}:
+			next, ok := value[token]
+			if !ok {
+				return nil, fmt.Errorf("path %q not found", pointer)
+			}
+			current = next
+		case []interface{}:
+			index, err := strconv.Atoi(token)
+			if err != nil || index < 0 || index >= len(value) {
+				return nil, fmt.Errorf("bad array index %q in %q", token, pointer)
+			}
+			current = value[index]
+		default:
+			return nil, fmt.Errorf("path %q not found", pointer)
+		}
+	}
+	return current, nil
+}
+
+// pointerSet writes a value at the pointer. With insert set, array indexes
+// insert before the existing element and "-" appends, per the add op;
+// otherwise the element is replaced.
+func pointerSet(document interface{}, pointer string, value interface{}, insert bool) (interface{}, error) {
+	tokens, err := pointerTokens(pointer)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return value, nil
+	}
+	return pointerApply(document, tokens, pointer, func(parent interface{}, token string) (interface{}, error) {
+		switch container := parent.(type) {
+		case map[string]interface{}:
+			if !insert {
+				if _, ok := container[token]; !ok {
+					return nil, fmt.Errorf("path %q not found", pointer)
+				}
+			}
+			container[token] = value
+			return container, nil
+		case []interface{}:
+			if insert && token == "-" {
+				return append(container, value), nil
+			}
+			index, err := strconv.Atoi(token)
+			if err != nil || index < 0 || index > len(container) || (!insert && index == len(container)) {
+				return nil, fmt.Errorf("bad array index %q in %q", token, pointer)
+			}
+			if !insert {
+				container[index] = value
+				return container, nil
+			}
+			container = append(container, nil)
+			copy(container[index+1:], container[index:])
+			container[index] = value
+			return container, nil
+		default:
+			return nil, fmt.Errorf("path %q not found", pointer)
+		}
+	})
+}
+
+// pointerRemove deletes the element at the pointer.
+func pointerRemove(document interface{}, pointer string) (interface{}, error) {
+	tokens, err := pointerTokens(pointer)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("cannot remove the whole document")
+	}
+	return pointerApply(document, tokens, pointer, func(parent interface{}, token string) (interface{}, error) {
+		switch container := parent.(type) {
+		case map[string]interface{}:
+			if _, ok := container[token]; !ok {
+				return nil, fmt.Errorf("path %q not found", pointer)
+			}
+			delete(container, token)
+			return container, nil
+		case []interface{}:
+			index, err := strconv.Atoi(token)
+			if err != nil || index < 0 || index >= len(container) {
+				return nil, fmt.Errorf("bad array index %q in %q", token, pointer)
+			}
+			return append(container[:index], container[index+1:]...), nil
+		default:
+			return nil, fmt.Errorf("path %q not found", pointer)
+		}
+	})
+}
+
+// pointerApply walks to the parent of the pointer's last token and applies
+// the edit there, rebuilding the spine so array reallocations propagate.
+func pointerApply(document interface{}, tokens []string, pointer string, edit func(parent interface{}, token string) (interface{}, error)) (interface{}, error) {
+	if len(tokens) == 1 {
+		return edit(document, tokens[0])
+	}
+	token := tokens[0]
+	switch container := document.(type) {
+	case map[string]interface{}:
+		child, ok := container[token]
+		if !ok {
+			return nil, fmt.Errorf("path %q not found", pointer)
+		}
+		edited, err := pointerApply(child, tokens[1:], pointer, edit)
+		if err != nil {
+			return nil, err
+		}
+		container[token] = edited
+		return container, nil
+	case []interface{}:
+		index, err := strconv.Atoi(token)
+		if err != nil || index < 0 || index >= len(container) {
+			return nil, fmt.Errorf("bad array index %q in %q", token, pointer)
+		}
+		edited, err := pointerApply(container[index], tokens[1:], pointer, edit)
+		if err != nil {
+			return nil, err
+		}
+		container[index] = edited
+		return container, nil
+	default:
+		return nil, fmt.Errorf("path %q not found", pointer)
+	}
+}
@@ -0,0 +1,38 @@
+package assets
+
+import "sort"
+
+// PathLength returns the number of hops in a linear chain — one less than
+// the nodes it visits, and 0 for a nil or single-node chain.
+func PathLength(path *ChainLink) int {
+	if path == nil {
+		return 0
+	}
+	return len(path.Steps()) - 1
+}
+
+// PathCost sums weightFn over the relationships of a linear chain.
+func PathCost(path *ChainLink, weightFn func(Relationship) float64) float64 {
+	cost := 0.0
+	for _, step := range path.Steps() {
+		if step.Relationship != nil {
+			cost += weightFn(*step.Relationship)
+		}
+	}
+	return cost
+}
+
+// SortPaths orders paths in place, best first: by the total weight from
+// weightFn, or by hop count when weightFn is nil. Ties keep their original
+// order.
+func SortPaths(paths []*ChainLink, weightFn func(Relationship) float64) {
+	cost := func(path *ChainLink) float64 {
+		if weightFn == nil {
+			return float64(PathLength(path))
+		}
+		return PathCost(path, weightFn)
+	}
+	sort.SliceStable(paths, func(i, j int) bool {
+		return cost(paths[i]) < cost(paths[j])
+	})
+}
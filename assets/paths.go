@@ -0,0 +1,193 @@
+package assets
+
+import (
+	"container/heap"
+	"context"
+	"time"
+)
+
+// ShortestPath returns a minimal connection between the two given nodes as a
+// linear chain of links rooted at from, found with a breadth-first search.
+// ErrNotFound is returned when either node is not part of the graph and
+// ErrNoPath when no connection exists between them. Traversal options control
+// the direction followed and the relationships considered.
+func (g *Graph) ShortestPath(from, to Node, opts ...TraversalOption) (*ChainLink, error) {
+	return g.ShortestPathCtx(context.Background(), from, to, opts...)
+}
+
+// ShortestPathCtx is ShortestPath with cancellation: the search checks the
+// context as it dequeues nodes and aborts with its error once it is
+// cancelled or its deadline passes.
+func (g *Graph) ShortestPathCtx(ctx context.Context, from, to Node, opts ...TraversalOption) (*ChainLink, error) {
+	if view := g.stableView(); view != nil {
+		began := time.Now()
+		chain, err := view.ShortestPathCtx(ctx, from, to, opts...)
+		if err != nil {
+			return nil, err
+		}
+		g.recordSlowQuery("ShortestPath", from.ID, began, chain.Len())
+		return chain, nil
+	}
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	if _, err := g.getNodeByID(from.ID); err != nil {
+		return nil, err
+	}
+	if _, err := g.getNodeByID(to.ID); err != nil {
+		return nil, err
+	}
+	began := time.Now()
+	options := newTraversalOptions(opts)
+	parents := map[string]string{from.ID: ""}
+	queue := []string{from.ID}
+	for len(queue) > 0 {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		current := queue[0]
+		queue = queue[1:]
+		if current == to.ID {
+			g.recordSlowQuery("ShortestPath", from.ID, began, len(parents))
+			return g.chainFromParents(parents, to.ID), nil
+		}
+		for _, e := range g.edgesFrom(current, options) {
+			if _, visited := parents[e.to]; visited {
+				continue
+			}
+			if _, err := g.getNodeByID(e.to); err != nil {
+				continue
+			}
+			parents[e.to] = current
+			queue = append(queue, e.to)
+		}
+	}
+	return nil, ErrNoPath
+}
+
+// ShortestPathWeighted returns the cheapest connection between the two given
+// nodes as a linear chain of links rooted at from, found with Dijkstra's
+// algorithm. The weight of each relationship is supplied by weightFn and must
+// not be negative. ErrNotFound is returned when either node is not part of
+// the graph and ErrNoPath when no connection exists between them.
+func (g *Graph) ShortestPathWeighted(from, to Node, weightFn func(Relationship) float64, opts ...TraversalOption) (*ChainLink, error) {
+	return g.ShortestPathWeightedCtx(context.Background(), from, to, weightFn, opts...)
+}
+
+// ShortestPathWeightedCtx is ShortestPathWeighted with cancellation,
+// checking the context as it dequeues nodes.
+func (g *Graph) ShortestPathWeightedCtx(ctx context.Context, from, to Node, weightFn func(Relationship) float64, opts ...TraversalOption) (*ChainLink, error) {
+	if view := g.stableView(); view != nil {
+		began := time.Now()
+		chain, err := view.ShortestPathWeightedCtx(ctx, from, to, weightFn, opts...)
+		if err != nil {
+			return nil, err
+		}
+		g.recordSlowQuery("ShortestPathWeighted", from.ID, began, chain.Len())
+		return chain, nil
+	}
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	if _, err := g.getNodeByID(from.ID); err != nil {
+		return nil, err
+	}
+	if _, err := g.getNodeByID(to.ID); err != nil {
+		return nil, err
+	}
+	began := time.Now()
+	options := newTraversalOptions(opts)
+	parents := map[string]string{from.ID: ""}
+	distances := map[string]float64{from.ID: 0}
+	done := map[string]bool{}
+	queue := &distanceQueue{{id: from.ID, distance: 0}}
+	for queue.Len() > 0 {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		current := heap.Pop(queue).(distanceEntry)
+		if done[current.id] {
+			continue
+		}
+		done[current.id] = true
+		if current.id == to.ID {
+			g.recordSlowQuery("ShortestPathWeighted", from.ID, began, len(done))
+			return g.chainFromParents(parents, to.ID), nil
+		}
+		for _, e := range g.edgesFrom(current.id, options) {
+			if done[e.to] {
+				continue
+			}
+			if _, err := g.getNodeByID(e.to); err != nil {
+				continue
+			}
+			distance := current.distance + weightFn(e.rel)
+			if known, seen := distances[e.to]; seen && known <= distance {
+				continue
+			}
+			distances[e.to] = distance
+			parents[e.to] = current.id
+			heap.Push(queue, distanceEntry{id: e.to, distance: distance})
+		}
+	}
+	return nil, ErrNoPath
+}
+
+// distanceEntry is a node queued by ShortestPathWeighted together with the
+// best distance known for it when it was queued.
+type distanceEntry struct {
+	id       string
+	distance float64
+}
+
+// distanceQueue is a min-heap of distance entries used by Dijkstra's
+// algorithm.
+type distanceQueue []distanceEntry
+
+func (q distanceQueue) Len() int            { return len(q) }
+func (q distanceQueue) Less(i, j int) bool  { return q[i].distance < q[j].distance }
+func (q distanceQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *distanceQueue) Push(x interface{}) { *q = append(*q, x.(distanceEntry)) }
+func (q *distanceQueue) Pop() interface{} {
+	old := *q
+	entry := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return entry
+}
+
+// chainFromParents rebuilds the path ending at the given node ID from a
+// parent map produced by a search, returning it as a chain rooted at the
+// start of the path. Callers must hold at least a read lock.
+func (g *Graph) chainFromParents(parents map[string]string, id string) *ChainLink {
+	var chain *ChainLink
+	for id != "" {
+		node, err := g.getNodeByID(id)
+		if err != nil {
+			return nil
+		}
+		link := &ChainLink{node: node}
+		if parent := parents[id]; parent != "" {
+			link.rel, _ = g.connectingRel(parent, id)
+		}
+		if chain != nil {
+			link.next = []*ChainLink{chain}
+		}
+		chain = link
+		id = parents[id]
+	}
+	return chain
+}
+
+// connectingRel returns a relationship joining the two given nodes in either
+// direction. Callers must hold at least a read lock.
+func (g *Graph) connectingRel(a, b string) (Relationship, bool) {
+	for relID := range g.relsByFrom[a] {
+		if rel := g.relationships[relID]; rel.To == b {
+			return rel, true
+		}
+	}
+	for relID := range g.relsByFrom[b] {
+		if rel := g.relationships[relID]; rel.To == a {
+			return rel, true
+		}
+	}
+	return Relationship{}, false
+}
@@ -0,0 +1,99 @@
+package assets
+
+import (
+	"errors"
+	"testing"
+)
+
+// chainNames flattens a single-path chain into its node names.
+func chainNames(t *testing.T, chain *ChainLink) []string {
+	t.Helper()
+	names := []string{}
+	for chain != nil {
+		names = append(names, chain.Node().Name)
+		switch len(chain.Next()) {
+		case 0:
+			chain = nil
+		case 1:
+			chain = chain.Next()[0]
+		default:
+			t.Fatalf("chain branches at %q; expected a single path", chain.Node().Name)
+		}
+	}
+	return names
+}
+
+func TestShortestPath(t *testing.T) {
+	g := NewGraph()
+	a, _ := g.AddNode("a", "server", nil)
+	b, _ := g.AddNode("b", "server", nil)
+	c, _ := g.AddNode("c", "server", nil)
+	d, _ := g.AddNode("d", "server", nil)
+	g.AddRelationship(a, b, "link")
+	g.AddRelationship(b, d, "link")
+	g.AddRelationship(a, c, "link")
+	g.AddRelationship(c, b, "link")
+
+	chain, err := g.ShortestPath(a, d)
+	if err != nil {
+		t.Fatalf("ShortestPath: %v", err)
+	}
+	names := chainNames(t, chain)
+	want := []string{"a", "b", "d"}
+	if len(names) != len(want) {
+		t.Fatalf("path %v, want %v", names, want)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Fatalf("path %v, want %v", names, want)
+		}
+	}
+}
+
+func TestShortestPathNoPath(t *testing.T) {
+	g := NewGraph()
+	a, _ := g.AddNode("a", "server", nil)
+	b, _ := g.AddNode("b", "server", nil)
+	if _, err := g.ShortestPath(a, b); !errors.Is(err, ErrNoPath) {
+		t.Errorf("ShortestPath on disconnected nodes: got %v, want ErrNoPath", err)
+	}
+}
+
+func TestShortestPathWeighted(t *testing.T) {
+	g := NewGraph()
+	a, _ := g.AddNode("a", "server", nil)
+	b, _ := g.AddNode("b", "server", nil)
+	c, _ := g.AddNode("c", "server", nil)
+	// The direct hop is cheapest by count but expensive by weight; the
+	// detour through c must win.
+	g.AddRelationship(a, b, "link", WithRelWeight(10))
+	g.AddRelationship(a, c, "link", WithRelWeight(1))
+	g.AddRelationship(c, b, "link", WithRelWeight(1))
+
+	chain, err := g.ShortestPathWeighted(a, b, StoredWeight)
+	if err != nil {
+		t.Fatalf("ShortestPathWeighted: %v", err)
+	}
+	names := chainNames(t, chain)
+	if len(names) != 3 || names[1] != "c" {
+		t.Errorf("weighted path %v, want the detour through c", names)
+	}
+}
+
+func TestListConnections(t *testing.T) {
+	g := NewGraph()
+	a, _ := g.AddNode("a", "server", nil)
+	b, _ := g.AddNode("b", "server", nil)
+	c, _ := g.AddNode("c", "server", nil)
+	g.AddRelationship(a, b, "link")
+	g.AddRelationship(b, c, "link")
+
+	chain, err := g.ListConnections(a)
+	if err != nil {
+		t.Fatalf("ListConnections: %v", err)
+	}
+	nodes := chain.Nodes()
+	if len(nodes) != 3 {
+		t.Errorf("traversal reached %d nodes, want 3", len(nodes))
+	}
+}
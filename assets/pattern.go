@@ -0,0 +1,107 @@
+package assets
+
+import "strconv"
+
+// Pattern describes a small motif to search for: a chain of node labels
+// connected by relationship labels, built fluently, e.g.
+//
+//	assets.NewPattern("service").As("svc").
+//		Out("runs_on", "host").
+//		Out("in", "region").As("region")
+//
+// An empty node label matches any node at that step.
+type Pattern struct {
+	steps []patternStep
+}
+
+// patternStep is one position in a pattern: the label of the node bound
+// there and the label of the relationship leading to it from the previous
+// step.
+type patternStep struct {
+	alias     string
+	nodeLabel string
+	relLabel  string
+}
+
+// NewPattern starts a pattern at nodes with the given label.
+func NewPattern(nodeLabel string) Pattern {
+	return Pattern{steps: []patternStep{{nodeLabel: nodeLabel}}}
+}
+
+// Out extends the pattern by one step: a relationship with the given label
+// leading to a node with the given label.
+func (p Pattern) Out(relLabel, nodeLabel string) Pattern {
+	steps := append(append([]patternStep{}, p.steps...), patternStep{nodeLabel: nodeLabel, relLabel: relLabel})
+	return Pattern{steps: steps}
+}
+
+// As names the most recent step so its binding appears under that alias in
+// every Match. Unnamed steps are bound as "n0", "n1", and so on.
+func (p Pattern) As(alias string) Pattern {
+	steps := append([]patternStep{}, p.steps...)
+	steps[len(steps)-1].alias = alias
+	return Pattern{steps: steps}
+}
+
+// Match is one complete binding of a pattern: the node bound at each step,
+// keyed by the step's alias.
+type Match map[string]Node
+
+// Match returns every binding of the pattern in the graph. Within one
+// binding each node is used at most once, so cycles do not produce
+// degenerate matches. Patterns are followed along outgoing relationships.
+func (g *Graph) Match(pattern Pattern) []Match {
+	if len(pattern.steps) == 0 {
+		return nil
+	}
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	matches := []Match{}
+	first := pattern.steps[0]
+	for id, node := range g.nodes {
+		if !g.nodeVisible(node) {
+			continue
+		}
+		if first.nodeLabel != "" && node.Label != first.nodeLabel {
+			continue
+		}
+		g.matchFrom(pattern, 1, []Node{node}, map[string]bool{id: true}, &matches)
+	}
+	return matches
+}
+
+// matchFrom extends a partial binding by the step at the given index,
+// recursing until the pattern is fully bound. Callers must hold at least a
+// read lock.
+func (g *Graph) matchFrom(pattern Pattern, index int, bound []Node, used map[string]bool, matches *[]Match) {
+	if index == len(pattern.steps) {
+		match := Match{}
+		for i, node := range bound {
+			alias := pattern.steps[i].alias
+			if alias == "" {
+				alias = "n" + strconv.Itoa(i)
+			}
+			match[alias] = node
+		}
+		*matches = append(*matches, match)
+		return
+	}
+	step := pattern.steps[index]
+	current := bound[len(bound)-1]
+	for relID := range g.relsByFrom[current.ID] {
+		rel := g.relationships[relID]
+		if rel.expiredAt(now()) || rel.Label != step.relLabel || used[rel.To] {
+			continue
+		}
+		next, err := g.getNodeByID(rel.To)
+		if err != nil {
+			continue
+		}
+		if step.nodeLabel != "" && next.Label != step.nodeLabel {
+			continue
+		}
+		used[rel.To] = true
+		g.matchFrom(pattern, index+1, append(bound, next), used, matches)
+		delete(used, rel.To)
+	}
+}
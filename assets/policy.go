@@ -0,0 +1,74 @@
+package assets
+
+import "fmt"
+
+// PolicyRule checks one invariant against the graph and returns every
+// violation found. Rules receive the read-only surface, so they can query
+// freely but cannot mutate.
+type PolicyRule func(g ReadOnlyGraph) []PolicyViolation
+
+// PolicyViolation is one breach of a registered policy.
+type PolicyViolation struct {
+	Policy  string `json:"policy"`
+	NodeID  string `json:"node_id,omitempty"`
+	Message string `json:"message"`
+}
+
+// RegisterPolicy registers a named invariant to be evaluated by
+// CheckPolicies.
+func (g *Graph) RegisterPolicy(name string, rule PolicyRule) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.policies = append(g.policies, policy{name: name, rule: rule})
+}
+
+// policy pairs a registered rule with its name.
+type policy struct {
+	name string
+	rule PolicyRule
+}
+
+// CheckPolicies evaluates every registered policy and returns all
+// violations, each stamped with the name of the policy it breaches. A graph
+// that satisfies all its invariants yields an empty slice.
+func (g *Graph) CheckPolicies() []PolicyViolation {
+	g.mu.RLock()
+	policies := append([]policy{}, g.policies...)
+	g.mu.RUnlock()
+	violations := []PolicyViolation{}
+	view := g.Freeze()
+	for _, p := range policies {
+		for _, violation := range p.rule(view) {
+			violation.Policy = p.name
+			violations = append(violations, violation)
+		}
+	}
+	return violations
+}
+
+// RequireRelationship builds the most common invariant: every node with
+// nodeLabel must have an outgoing relationship with relLabel to a node with
+// targetLabel, e.g.
+//
+//	g.RegisterPolicy("services are owned", assets.RequireRelationship("service", "owned_by", "team"))
+func RequireRelationship(nodeLabel, relLabel, targetLabel string) PolicyRule {
+	return func(g ReadOnlyGraph) []PolicyViolation {
+		violations := []PolicyViolation{}
+		for _, node := range g.ListNodes(FilterNodesByLabel(nodeLabel)) {
+			satisfied := false
+			for _, rel := range g.ListRelationships(FilterRelByFrom(node.ID), FilterRelByLabel(relLabel)) {
+				if target, err := g.GetNodeByID(rel.To); err == nil && target.Label == targetLabel {
+					satisfied = true
+					break
+				}
+			}
+			if !satisfied {
+				violations = append(violations, PolicyViolation{
+					NodeID:  node.ID,
+					Message: fmt.Sprintf("node %q (%s) has no %q relationship to a %q", node.Name, nodeLabel, relLabel, targetLabel),
+				})
+			}
+		}
+		return violations
+	}
+}
@@ -0,0 +1,58 @@
+package assets
+
+import (
+	"log/slog"
+	"time"
+)
+
+// SlowQuery records one traversal that exceeded the configured threshold:
+// which operation ran, where it started, how long it took and how many nodes
+// it touched — enough to diagnose pathological graph shapes in production.
+type SlowQuery struct {
+	Operation string        `json:"operation"`
+	StartID   string        `json:"start_id"`
+	Duration  time.Duration `json:"duration"`
+	Visited   int           `json:"visited"`
+	At        time.Time     `json:"at"`
+}
+
+// WithSlowQueryThreshold makes the graph record every traversal taking
+// longer than d, retrievable with SlowQueries and logged at warn level when
+// a logger is configured.
+func WithSlowQueryThreshold(d time.Duration) GraphOption {
+	return func(g *Graph) {
+		g.slowThreshold = d
+	}
+}
+
+// SlowQueries returns the recorded slow traversals, oldest first.
+func (g *Graph) SlowQueries() []SlowQuery {
+	g.slowMu.Lock()
+	defer g.slowMu.Unlock()
+	return append([]SlowQuery{}, g.slowQueries...)
+}
+
+// recordSlowQuery captures a traversal if it crossed the threshold. It uses
+// its own lock, so traversals holding the graph's read lock can call it
+// safely.
+func (g *Graph) recordSlowQuery(operation, startID string, began time.Time, visited int) {
+	if g.slowThreshold <= 0 {
+		return
+	}
+	duration := time.Since(began)
+	if duration < g.slowThreshold {
+		return
+	}
+	record := SlowQuery{
+		Operation: operation,
+		StartID:   startID,
+		Duration:  duration,
+		Visited:   visited,
+		At:        began,
+	}
+	g.slowMu.Lock()
+	g.slowQueries = append(g.slowQueries, record)
+	g.slowMu.Unlock()
+	g.logEvent(slog.LevelWarn, "slow traversal",
+		"operation", operation, "start_id", startID, "duration", duration, "visited", visited)
+}
@@ -0,0 +1,109 @@
+package assets
+
+// WithProperties sets structured attributes on the node being added. The map
+// is copied, so the caller can keep mutating its own.
+func WithProperties(props map[string]any) NodeOption {
+	return func(n *Node) {
+		if n.Properties == nil {
+			n.Properties = make(map[string]any, len(props))
+		}
+		for key, value := range props {
+			n.Properties[key] = value
+		}
+	}
+}
+
+// Property returns the named property and whether it is set.
+func (n Node) Property(key string) (any, bool) {
+	value, ok := n.Properties[key]
+	return value, ok
+}
+
+// PropertyString returns the named property as a string, or "" when it is
+// unset or not a string.
+func (n Node) PropertyString(key string) string {
+	value, _ := n.Properties[key].(string)
+	return value
+}
+
+// PropertyNumber returns the named property as a float64, or 0 when it is
+// unset or not numeric. Integer-typed values are converted.
+func (n Node) PropertyNumber(key string) float64 {
+	number, _ := asNumber(n.Properties[key])
+	return number
+}
+
+// PropertyBool returns the named property as a bool, or false when it is
+// unset or not a bool.
+func (n Node) PropertyBool(key string) bool {
+	value, _ := n.Properties[key].(bool)
+	return value
+}
+
+// PropertyPredicate tests a single property value; compose one with
+// FilterNodesByProperty.
+type PropertyPredicate func(any) bool
+
+// Equals matches properties deeply equal to want, with numeric values
+// compared across int and float types.
+func Equals(want any) PropertyPredicate {
+	return func(value any) bool {
+		if wantNumber, ok := asNumber(want); ok {
+			number, ok := asNumber(value)
+			return ok && number == wantNumber
+		}
+		return value == want
+	}
+}
+
+// GreaterThan matches numeric properties strictly greater than want.
+func GreaterThan(want float64) PropertyPredicate {
+	return func(value any) bool {
+		number, ok := asNumber(value)
+		return ok && number > want
+	}
+}
+
+// LessThan matches numeric properties strictly less than want.
+func LessThan(want float64) PropertyPredicate {
+	return func(value any) bool {
+		number, ok := asNumber(value)
+		return ok && number < want
+	}
+}
+
+// FilterNodesByProperty matches nodes whose named property exists and
+// satisfies the predicate, e.g.
+//
+//	g.ListNodes(assets.FilterNodesByProperty("power", assets.GreaterThan(499)))
+func FilterNodesByProperty(key string, pred PropertyPredicate) Filter {
+	return func(n Node) bool {
+		value, ok := n.Properties[key]
+		return ok && pred(value)
+	}
+}
+
+// asNumber converts the numeric types a property can realistically hold —
+// including float64 from JSON decoding — to float64.
+func asNumber(value any) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint:
+		return float64(v), true
+	case uint32:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	default:
+		return 0, false
+	}
+}
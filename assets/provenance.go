@@ -0,0 +1,90 @@
+package assets
+
+import (
+	"errors"
+	"time"
+)
+
+// Provenance records where a fact in the graph came from: the system that
+// observed it, when it was observed, and how much the source is trusted.
+type Provenance struct {
+	Source     string    `json:"source"`
+	ObservedAt time.Time `json:"observed_at"`
+	Confidence float64   `json:"confidence"`
+}
+
+// supersedes reports whether this observation should replace the existing
+// one: higher confidence wins, and at equal confidence the fresher
+// observation wins. Anything supersedes missing provenance.
+func (p Provenance) supersedes(existing *Provenance) bool {
+	if existing == nil {
+		return true
+	}
+	if p.Confidence != existing.Confidence {
+		return p.Confidence > existing.Confidence
+	}
+	return p.ObservedAt.After(existing.ObservedAt)
+}
+
+// WithProvenance records the provenance of the node being added.
+func WithProvenance(p Provenance) NodeOption {
+	return func(n *Node) {
+		n.Provenance = &p
+	}
+}
+
+// WithRelProvenance records the provenance of the relationship being added.
+func WithRelProvenance(p Provenance) RelOption {
+	return func(r *Relationship) {
+		r.Provenance = &p
+	}
+}
+
+// ObserveNode upserts a node by name and label on behalf of a scanner: a
+// previously unseen asset is inserted, and a known one is updated only when
+// the new observation supersedes the stored provenance. Lower-confidence or
+// staler scanners therefore never stomp better data.
+func (g *Graph) ObserveNode(name, label string, body []byte, p Provenance, opts ...NodeOption) (Node, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	existing, err := g.lookupByNameAndLabel(name, label)
+	if errors.Is(err, ErrNotFound) {
+		return g.addNode(name, label, body, append([]NodeOption{WithProvenance(p)}, opts...))
+	}
+	if err != nil {
+		return Node{}, err
+	}
+	if !p.supersedes(existing.Provenance) {
+		return existing, nil
+	}
+	node := existing
+	g.recordRevision(node)
+	node.Body = body
+	node.Provenance = &p
+	node.Version++
+	node.UpdatedAt = now()
+	if g.actor != "" {
+		node.UpdatedBy = g.actor
+	}
+	if err := runBefore(g.hooks.beforeUpdateNode, &node); err != nil {
+		return Node{}, err
+	}
+	if err := g.validateBody(node.Label, node.Body); err != nil {
+		return Node{}, err
+	}
+	g.putNode(node)
+	if err := g.logMutation(walEntry{Op: opUpdateNode, Node: &node}); err != nil {
+		return Node{}, err
+	}
+	g.emitAfter(func() { runAfter(g.hooks.afterUpdateNode, node) })
+	return node, nil
+}
+
+// PreferProvenance is a MergeStrategy that keeps whichever node's
+// observation supersedes the other's, falling back to ours on a tie.
+func PreferProvenance(ours, theirs Node) Node {
+	if theirs.Provenance != nil && theirs.Provenance.supersedes(ours.Provenance) {
+		return theirs
+	}
+	return ours
+}
@@ -0,0 +1,129 @@
+package assets
+
+// Query is a fluent, Gremlin-style traversal over the graph. Each step moves
+// or narrows the current set of nodes and returns the query so steps can be
+// chained, e.g.
+//
+//	grf.V().HasLabel("puppy").Out("friends").HasLabel("dragon").Nodes()
+//
+// A query never holds the graph lock between steps, so each step observes the
+// graph as it is when the step runs.
+type Query struct {
+	graph *Graph
+	ids   []string
+}
+
+// V starts a query selecting every node in the graph.
+func (g *Graph) V() *Query {
+	query := &Query{graph: g}
+	for _, node := range g.ListNodes() {
+		query.ids = append(query.ids, node.ID)
+	}
+	return query
+}
+
+// HasLabel keeps only the selected nodes carrying one of the given labels.
+func (q *Query) HasLabel(labels ...string) *Query {
+	wanted := map[string]bool{}
+	for _, label := range labels {
+		wanted[label] = true
+	}
+	return q.Has(func(n Node) bool {
+		return wanted[n.Label]
+	})
+}
+
+// HasName keeps only the selected nodes carrying one of the given names.
+func (q *Query) HasName(names ...string) *Query {
+	wanted := map[string]bool{}
+	for _, name := range names {
+		wanted[name] = true
+	}
+	return q.Has(func(n Node) bool {
+		return wanted[n.Name]
+	})
+}
+
+// Has keeps only the selected nodes matching all of the given filters.
+func (q *Query) Has(filters ...Filter) *Query {
+	kept := []string{}
+	for _, node := range q.Nodes() {
+		if matchesNode(node, filters) {
+			kept = append(kept, node.ID)
+		}
+	}
+	q.ids = kept
+	return q
+}
+
+// Out moves the selection to the nodes reachable over outgoing
+// relationships, optionally restricted to relationships carrying one of the
+// given labels.
+func (q *Query) Out(labels ...string) *Query {
+	return q.step(Outgoing, labels)
+}
+
+// In moves the selection to the nodes reachable over incoming relationships,
+// optionally restricted to relationships carrying one of the given labels.
+func (q *Query) In(labels ...string) *Query {
+	return q.step(Incoming, labels)
+}
+
+// Both moves the selection to the nodes reachable over relationships in
+// either direction, optionally restricted to relationships carrying one of
+// the given labels.
+func (q *Query) Both(labels ...string) *Query {
+	return q.step(Undirected, labels)
+}
+
+func (q *Query) step(direction Direction, labels []string) *Query {
+	opts := []TraversalOption{WithDirection(direction)}
+	if len(labels) > 0 {
+		wanted := map[string]bool{}
+		for _, label := range labels {
+			wanted[label] = true
+		}
+		opts = append(opts, WithRelFilters(func(r Relationship) bool {
+			return wanted[r.Label]
+		}))
+	}
+	seen := map[string]bool{}
+	next := []string{}
+	for _, id := range q.ids {
+		for _, neighbor := range q.graph.Neighbors(id, opts...) {
+			if seen[neighbor.ID] {
+				continue
+			}
+			seen[neighbor.ID] = true
+			next = append(next, neighbor.ID)
+		}
+	}
+	q.ids = next
+	return q
+}
+
+// Nodes materializes the query, returning the currently selected nodes.
+// Nodes deleted since the previous step are skipped.
+func (q *Query) Nodes() []Node {
+	nodes := []Node{}
+	for _, id := range q.ids {
+		node, err := q.graph.GetNodeByID(id)
+		if err != nil {
+			continue
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+
+// IDs returns the IDs of the currently selected nodes.
+func (q *Query) IDs() []string {
+	ids := make([]string, len(q.ids))
+	copy(ids, q.ids)
+	return ids
+}
+
+// Count returns the number of currently selected nodes.
+func (q *Query) Count() int {
+	return len(q.ids)
+}
@@ -0,0 +1,64 @@
+package assets
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+)
+
+// IRIs used when mapping the graph onto RDF. Nodes, labels and relationship
+// labels all live under the urn:asset: namespace; names use rdfs:label so
+// generic tooling displays them.
+const (
+	rdfTypeIRI  = "http://www.w3.org/1999/02/22-rdf-syntax-ns#type"
+	rdfsLabel   = "http://www.w3.org/2000/01/rdf-schema#label"
+	rdfAssetURN = "urn:asset:"
+)
+
+// ExportNTriples writes the graph as RDF N-Triples. Node labels are mapped
+// to rdf:type statements, node names to rdfs:label literals and
+// relationships to predicates derived from their label, enabling interop
+// with semantic-web and knowledge-graph tooling.
+func (g *Graph) ExportNTriples(w io.Writer) error {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	for _, node := range sortedNodes(g.nodes) {
+		subject := rdfNodeIRI(node.ID)
+		triples := []string{
+			fmt.Sprintf("%s <%s> %s .\n", subject, rdfTypeIRI, rdfAssetIRI("label", node.Label)),
+			fmt.Sprintf("%s <%s> %s .\n", subject, rdfsLabel, rdfLiteral(node.Name)),
+		}
+		if len(node.Body) > 0 {
+			triples = append(triples, fmt.Sprintf("%s %s %s .\n", subject, rdfAssetIRI("property", "body"), rdfLiteral(string(node.Body))))
+		}
+		for _, triple := range triples {
+			if _, err := io.WriteString(w, triple); err != nil {
+				return err
+			}
+		}
+	}
+	for _, rel := range sortedRelationships(g.relationships) {
+		triple := fmt.Sprintf("%s %s %s .\n", rdfNodeIRI(rel.From), rdfAssetIRI("rel", rel.Label), rdfNodeIRI(rel.To))
+		if _, err := io.WriteString(w, triple); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func rdfNodeIRI(id string) string {
+	return rdfAssetIRI("node", id)
+}
+
+func rdfAssetIRI(kind, value string) string {
+	if value == "" {
+		value = "unlabeled"
+	}
+	return fmt.Sprintf("<%s%s:%s>", rdfAssetURN, kind, url.PathEscape(value))
+}
+
+// rdfLiteral quotes a value as an N-Triples string literal.
+func rdfLiteral(s string) string {
+	return "\"" + strings.NewReplacer("\\", "\\\\", "\"", "\\\"", "\n", "\\n", "\r", "\\r").Replace(s) + "\""
+}
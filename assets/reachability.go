@@ -0,0 +1,53 @@
+package assets
+
+// Reachable returns every node that can be reached from the node with the
+// given ID, not including the node itself. By default relationships are
+// followed in the From->To direction; traversal options control the
+// direction and which relationships are considered.
+func (g *Graph) Reachable(fromID string, opts ...TraversalOption) []Node {
+	return g.reachable(fromID, newTraversalOptions(opts))
+}
+
+// Dependents returns every node from which the node with the given ID can be
+// reached — the impact set if that asset goes down. It is Reachable with the
+// direction reversed; relationship filters from the options still apply.
+func (g *Graph) Dependents(toID string, opts ...TraversalOption) []Node {
+	options := newTraversalOptions(opts)
+	switch options.direction {
+	case Outgoing:
+		options.direction = Incoming
+	case Incoming:
+		options.direction = Outgoing
+	}
+	return g.reachable(toID, options)
+}
+
+// reachable performs a breadth-first expansion from the given node under the
+// resolved options.
+func (g *Graph) reachable(startID string, options traversalOptions) []Node {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	if _, err := g.getNodeByID(startID); err != nil {
+		return nil
+	}
+	visited := map[string]bool{startID: true}
+	queue := []string{startID}
+	nodes := []Node{}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, e := range g.edgesFrom(current, options) {
+			if visited[e.to] {
+				continue
+			}
+			visited[e.to] = true
+			node, err := g.getNodeByID(e.to)
+			if err != nil {
+				continue
+			}
+			nodes = append(nodes, node)
+			queue = append(queue, e.to)
+		}
+	}
+	return nodes
+}
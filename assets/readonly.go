@@ -0,0 +1,25 @@
+package assets
+
+// ReadOnlyGraph is the query-only surface of a graph. Handing analysis code
+// a ReadOnlyGraph gives a compile-time guarantee that it cannot mutate the
+// underlying graph. Both *Graph and GraphView satisfy it.
+type ReadOnlyGraph interface {
+	GetNodeByID(id string) (Node, error)
+	GetNodesByName(name string) []Node
+	ListNodes(filters ...Filter) []Node
+	ListRelationships(filters ...FilterRelationship) []Relationship
+	ListConnections(start Node, opts ...TraversalOption) (*ChainLink, error)
+	Neighbors(nodeID string, opts ...TraversalOption) []Node
+}
+
+var (
+	_ ReadOnlyGraph = (*Graph)(nil)
+	_ ReadOnlyGraph = GraphView{}
+)
+
+// Freeze returns a read-only handle onto the live graph. Unlike Snapshot it
+// copies nothing: reads through the handle observe concurrent writes, but
+// the handle exposes no mutators.
+func (g *Graph) Freeze() ReadOnlyGraph {
+	return GraphView{graph: g}
+}
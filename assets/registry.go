@@ -0,0 +1,180 @@
+package assets
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// snapshotExtension is the file suffix used for per-namespace snapshots
+// written by Registry.SaveAll.
+const snapshotExtension = ".snapshot"
+
+// crossRelsFile is the sidecar file SaveAll writes next to the per-namespace
+// snapshots to persist cross-graph relationships.
+const crossRelsFile = "cross-relationships.json"
+
+// Registry manages one graph per namespace — per environment, per tenant —
+// behind a single handle. Graphs are created on first use with the
+// configuration the registry was built with, so every namespace enforces the
+// same constraints.
+type Registry struct {
+	mu        sync.RWMutex
+	options   []GraphOption
+	graphs    map[string]*Graph
+	crossRels []CrossRelationship
+}
+
+// NewRegistry returns an empty registry. The given options are applied to
+// every graph it creates.
+func NewRegistry(opts ...GraphOption) *Registry {
+	return &Registry{
+		options: opts,
+		graphs:  map[string]*Graph{},
+	}
+}
+
+// Graph returns the graph for the given namespace, creating it on first use.
+// New graphs are named after their namespace.
+func (r *Registry) Graph(namespace string) *Graph {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if graph, ok := r.graphs[namespace]; ok {
+		return graph
+	}
+	graph := NewGraph(r.options...)
+	graph.SetName(namespace)
+	r.graphs[namespace] = graph
+	return graph
+}
+
+// Namespaces returns the registered namespaces in ascending order.
+func (r *Registry) Namespaces() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	namespaces := make([]string, 0, len(r.graphs))
+	for namespace := range r.graphs {
+		namespaces = append(namespaces, namespace)
+	}
+	sort.Strings(namespaces)
+	return namespaces
+}
+
+// Drop removes the graph of the given namespace from the registry.
+func (r *Registry) Drop(namespace string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.graphs, namespace)
+}
+
+// Search runs a full-text search across every namespace and returns the
+// matches grouped by namespace. Namespaces without matches are omitted.
+func (r *Registry) Search(query string, opts ...SearchOption) map[string][]Node {
+	r.mu.RLock()
+	graphs := make(map[string]*Graph, len(r.graphs))
+	for namespace, graph := range r.graphs {
+		graphs[namespace] = graph
+	}
+	r.mu.RUnlock()
+	results := map[string][]Node{}
+	for namespace, graph := range graphs {
+		if nodes := graph.Search(query, opts...); len(nodes) > 0 {
+			results[namespace] = nodes
+		}
+	}
+	return results
+}
+
+// SaveAll writes one snapshot per namespace into the given directory, named
+// "<namespace>.snapshot", plus a sidecar file holding the cross-graph
+// relationships, creating the directory if needed.
+func (r *Registry) SaveAll(dir string, opts ...SaveOption) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("creating snapshot directory: %w", err)
+	}
+	for _, namespace := range r.Namespaces() {
+		if err := validateSnapshotName(namespace); err != nil {
+			return err
+		}
+		graph := r.Graph(namespace)
+		file, err := os.Create(filepath.Join(dir, namespace+snapshotExtension))
+		if err != nil {
+			return fmt.Errorf("creating snapshot for namespace %q: %w", namespace, err)
+		}
+		if err := graph.Save(file, opts...); err != nil {
+			file.Close()
+			return fmt.Errorf("saving namespace %q: %w", namespace, err)
+		}
+		if err := file.Close(); err != nil {
+			return err
+		}
+	}
+	encoded, err := json.Marshal(r.CrossRelationships())
+	if err != nil {
+		return fmt.Errorf("encoding cross-graph relationships: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, crossRelsFile), encoded, 0o644); err != nil {
+		return fmt.Errorf("saving cross-graph relationships: %w", err)
+	}
+	return nil
+}
+
+// validateSnapshotName rejects namespaces that cannot be used as a file name
+// inside the snapshot directory, so a namespace like "../etc/cron.d/x" can
+// never write outside it.
+func validateSnapshotName(namespace string) error {
+	if namespace == "" || namespace == "." || namespace == ".." ||
+		strings.ContainsAny(namespace, `/\`) || namespace != filepath.Base(namespace) {
+		return fmt.Errorf("namespace %q cannot be used as a snapshot file name", namespace)
+	}
+	return nil
+}
+
+// LoadRegistry restores a registry from a directory written by SaveAll,
+// loading every "*.snapshot" file as the namespace it is named after. The
+// given options are applied to graphs created after loading.
+func LoadRegistry(dir string, opts ...GraphOption) (*Registry, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("reading snapshot directory: %w", err)
+	}
+	registry := NewRegistry(opts...)
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), snapshotExtension) {
+			continue
+		}
+		namespace := strings.TrimSuffix(entry.Name(), snapshotExtension)
+		file, err := os.Open(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("opening snapshot for namespace %q: %w", namespace, err)
+		}
+		graph, err := Load(file)
+		file.Close()
+		if err != nil {
+			return nil, fmt.Errorf("loading namespace %q: %w", namespace, err)
+		}
+		if graph.Name() == "" {
+			graph.SetName(namespace)
+		}
+		registry.mu.Lock()
+		registry.graphs[namespace] = graph
+		registry.mu.Unlock()
+	}
+	encoded, err := os.ReadFile(filepath.Join(dir, crossRelsFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			// Directories written before cross-graph relationships existed
+			// have no sidecar file.
+			return registry, nil
+		}
+		return nil, fmt.Errorf("reading cross-graph relationships: %w", err)
+	}
+	if err := json.Unmarshal(encoded, &registry.crossRels); err != nil {
+		return nil, fmt.Errorf("loading cross-graph relationships: %w", err)
+	}
+	return registry, nil
+}
@@ -0,0 +1,138 @@
+package assets
+
+import "time"
+
+// Relationship is a directed, labeled edge between two nodes in the graph.
+// From and To hold the IDs of the nodes at either end. The optional Body
+// carries an arbitrary payload, such as version constraints or weights.
+type Relationship struct {
+	ID    string `json:"id"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+	Label string `json:"label"`
+	Body  []byte `json:"body,omitempty"`
+	// Weight is an optional cost, capacity or trust value set with
+	// WithRelWeight. A zero weight means unweighted; StoredWeight treats it
+	// as 1 so weighted algorithms degrade to hop counting.
+	Weight float64 `json:"weight,omitempty"`
+	// CreatedAt and UpdatedAt are maintained automatically on insert and
+	// update.
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+	// CreatedBy and UpdatedBy attribute the relationship to the actor that
+	// asserted it. See AsRelActor and Graph.SetActor.
+	CreatedBy string `json:"created_by,omitempty"`
+	UpdatedBy string `json:"updated_by,omitempty"`
+	// ExpiresAt is the optional TTL deadline set with WithRelTTL; expired
+	// relationships are no longer followed and are removed by the reaper.
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	// Undirected marks a relationship with no natural direction, such as a
+	// network peering. Traversals follow it both ways regardless of the
+	// traversal direction; From and To merely record how it was inserted.
+	Undirected bool `json:"undirected,omitempty"`
+	// ValidFrom and ValidUntil optionally bound the real-world validity of
+	// the relationship, independent of when it was recorded. See
+	// WithRelValidity and the ActiveAt traversal option.
+	ValidFrom  *time.Time `json:"valid_from,omitempty"`
+	ValidUntil *time.Time `json:"valid_until,omitempty"`
+	// Provenance records which system observed the relationship, when, and
+	// with what confidence.
+	Provenance *Provenance `json:"provenance,omitempty"`
+}
+
+// activeAt reports whether the relationship was valid at the given time. A
+// zero bound leaves that side open.
+func (r Relationship) activeAt(t time.Time) bool {
+	if r.ValidFrom != nil && t.Before(*r.ValidFrom) {
+		return false
+	}
+	if r.ValidUntil != nil && !t.Before(*r.ValidUntil) {
+		return false
+	}
+	return true
+}
+
+// RelOption configures a relationship as it is added to the graph.
+type RelOption func(*Relationship)
+
+// WithRelBody attaches an arbitrary payload to the relationship.
+func WithRelBody(body []byte) RelOption {
+	return func(r *Relationship) {
+		r.Body = body
+	}
+}
+
+// WithRelWeight sets the weight of the relationship, for modelling cost,
+// bandwidth or trust between assets.
+func WithRelWeight(weight float64) RelOption {
+	return func(r *Relationship) {
+		r.Weight = weight
+	}
+}
+
+// WithRelValidity bounds when the relationship held in the real world, so
+// historical topology can be reconstructed later with ActiveAt. Either bound
+// may be the zero time to leave that side open.
+func WithRelValidity(from, until time.Time) RelOption {
+	return func(r *Relationship) {
+		if !from.IsZero() {
+			f := from
+			r.ValidFrom = &f
+		}
+		if !until.IsZero() {
+			u := until
+			r.ValidUntil = &u
+		}
+	}
+}
+
+// StoredWeight is a weight function for the weighted path algorithms that
+// reads the weight recorded on each relationship, treating unweighted
+// relationships as weight 1.
+func StoredWeight(rel Relationship) float64 {
+	if rel.Weight == 0 {
+		return 1
+	}
+	return rel.Weight
+}
+
+// RelUpdateOption changes a field on an existing relationship.
+type RelUpdateOption func(*Relationship)
+
+// RelSetLabel changes the label of the relationship being updated.
+func RelSetLabel(label string) RelUpdateOption {
+	return func(r *Relationship) {
+		r.Label = label
+	}
+}
+
+// RelSetBody replaces the payload of the relationship being updated.
+func RelSetBody(body []byte) RelUpdateOption {
+	return func(r *Relationship) {
+		r.Body = body
+	}
+}
+
+// RelSetWeight changes the weight of the relationship being updated.
+func RelSetWeight(weight float64) RelUpdateOption {
+	return func(r *Relationship) {
+		r.Weight = weight
+	}
+}
+
+// RelSetValidity changes the validity window of the relationship being
+// updated, typically to close an open-ended relationship that has since
+// ended. A zero bound leaves that side open.
+func RelSetValidity(from, until time.Time) RelUpdateOption {
+	return func(r *Relationship) {
+		r.ValidFrom, r.ValidUntil = nil, nil
+		if !from.IsZero() {
+			f := from
+			r.ValidFrom = &f
+		}
+		if !until.IsZero() {
+			u := until
+			r.ValidUntil = &u
+		}
+	}
+}
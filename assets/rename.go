@@ -0,0 +1,62 @@
+package assets
+
+import (
+	"fmt"
+	"log/slog"
+)
+
+// RenameNode changes the name of the node with the given ID, keeping its ID,
+// body and relationships intact and the name index consistent. Under
+// WithUniqueNames the new name must not collide with another node of the
+// same label.
+func (g *Graph) RenameNode(id, newName string) (Node, error) {
+	return g.reidentifyNode(id, func(node *Node) {
+		node.Name = newName
+	})
+}
+
+// RelabelNode changes the label of the node with the given ID, keeping its
+// ID, body and relationships intact and the label and body indexes
+// consistent. The body is re-validated against the schema registered for the
+// new label, if any.
+func (g *Graph) RelabelNode(id, newLabel string) (Node, error) {
+	return g.reidentifyNode(id, func(node *Node) {
+		node.Label = newLabel
+	})
+}
+
+// reidentifyNode runs a name or label change through the same machinery as a
+// body update: revision history, hooks, validation and the WAL.
+func (g *Graph) reidentifyNode(id string, change func(*Node)) (Node, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	node, ok := g.nodes[id]
+	if !ok || node.isDeleted() {
+		return Node{}, ErrNotFound
+	}
+	g.recordRevision(node)
+	change(&node)
+	node.Version++
+	node.UpdatedAt = now()
+	if g.actor != "" {
+		node.UpdatedBy = g.actor
+	}
+	if g.uniqueNames {
+		if existing, err := g.lookupByNameAndLabel(node.Name, node.Label); err == nil && existing.ID != node.ID {
+			return Node{}, fmt.Errorf("%w: node %q with label %q", ErrAlreadyExists, node.Name, node.Label)
+		}
+	}
+	if err := runBefore(g.hooks.beforeUpdateNode, &node); err != nil {
+		return Node{}, err
+	}
+	if err := g.validateBody(node.Label, node.Body); err != nil {
+		g.logEvent(slog.LevelWarn, "node update rejected", "id", node.ID, "label", node.Label, "error", err)
+		return Node{}, err
+	}
+	g.putNode(node)
+	if err := g.logMutation(walEntry{Op: opUpdateNode, Node: &node}); err != nil {
+		return Node{}, err
+	}
+	g.emitAfter(func() { runAfter(g.hooks.afterUpdateNode, node) })
+	return node, nil
+}
@@ -0,0 +1,298 @@
+package assets
+
+import (
+	"fmt"
+	"hash/fnv"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"math"
+)
+
+// RenderOption configures the SVG and PNG renderers.
+type RenderOption func(*renderOptions)
+
+type renderOptions struct {
+	width      int
+	height     int
+	iterations int
+	filters    []Filter
+}
+
+// WithRenderSize sets the output dimensions in pixels. The default is
+// 1024x768.
+func WithRenderSize(width, height int) RenderOption {
+	return func(o *renderOptions) {
+		o.width = width
+		o.height = height
+	}
+}
+
+// WithRenderIterations sets how many force-layout iterations are run before
+// drawing. More iterations spread dense graphs further apart.
+func WithRenderIterations(n int) RenderOption {
+	return func(o *renderOptions) {
+		o.iterations = n
+	}
+}
+
+// WithRenderFilters restricts rendering to nodes matching every filter,
+// together with the relationships between them.
+func WithRenderFilters(filters ...Filter) RenderOption {
+	return func(o *renderOptions) {
+		o.filters = filters
+	}
+}
+
+// layoutNode is a node with a computed position.
+type layoutNode struct {
+	node Node
+	x, y float64
+	vx   float64
+	vy   float64
+}
+
+// layoutEdge indexes into the layout's node slice.
+type layoutEdge struct {
+	from, to int
+	label    string
+}
+
+// ExportSVG lays the graph out with a force-directed simulation and writes
+// it as an SVG document, with nodes colored by label. No external tool is
+// involved, so diagrams can be generated inside reports and CI jobs where
+// graphviz is unavailable.
+func (g *Graph) ExportSVG(w io.Writer, opts ...RenderOption) error {
+	options, nodes, edges := g.layout(opts)
+	fmt.Fprintf(w, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%d\" height=\"%d\" font-family=\"sans-serif\">\n", options.width, options.height)
+	fmt.Fprintf(w, "  <rect width=\"100%%\" height=\"100%%\" fill=\"white\"/>\n")
+	for _, edge := range edges {
+		from, to := nodes[edge.from], nodes[edge.to]
+		fmt.Fprintf(w, "  <line x1=\"%.1f\" y1=\"%.1f\" x2=\"%.1f\" y2=\"%.1f\" stroke=\"#bbbbbb\"/>\n", from.x, from.y, to.x, to.y)
+		if edge.label != "" {
+			fmt.Fprintf(w, "  <text x=\"%.1f\" y=\"%.1f\" font-size=\"9\" fill=\"#888888\">%s</text>\n",
+				(from.x+to.x)/2, (from.y+to.y)/2, xmlEscape(edge.label))
+		}
+	}
+	for _, ln := range nodes {
+		r, gr, b := labelColor(ln.node.Label)
+		fmt.Fprintf(w, "  <circle cx=\"%.1f\" cy=\"%.1f\" r=\"6\" fill=\"rgb(%d,%d,%d)\"/>\n", ln.x, ln.y, r, gr, b)
+		fmt.Fprintf(w, "  <text x=\"%.1f\" y=\"%.1f\" font-size=\"11\" fill=\"#333333\">%s</text>\n",
+			ln.x+9, ln.y+4, xmlEscape(ln.node.Name))
+	}
+	_, err := fmt.Fprintln(w, "</svg>")
+	return err
+}
+
+// ExportPNG renders the same layout as ExportSVG directly to a PNG image,
+// for consumers that cannot embed vector output.
+func (g *Graph) ExportPNG(w io.Writer, opts ...RenderOption) error {
+	options, nodes, edges := g.layout(opts)
+	img := image.NewRGBA(image.Rect(0, 0, options.width, options.height))
+	fill(img, color.White)
+	grey := color.RGBA{R: 0xbb, G: 0xbb, B: 0xbb, A: 0xff}
+	for _, edge := range edges {
+		from, to := nodes[edge.from], nodes[edge.to]
+		drawLine(img, int(from.x), int(from.y), int(to.x), int(to.y), grey)
+	}
+	for _, ln := range nodes {
+		r, gr, b := labelColor(ln.node.Label)
+		drawDisc(img, int(ln.x), int(ln.y), 6, color.RGBA{R: r, G: gr, B: b, A: 0xff})
+	}
+	return png.Encode(w, img)
+}
+
+// layout runs the force simulation shared by both renderers. Initial
+// positions are derived from node IDs, so rendering the same graph twice
+// yields the same picture.
+func (g *Graph) layout(opts []RenderOption) (renderOptions, []layoutNode, []layoutEdge) {
+	options := renderOptions{width: 1024, height: 768, iterations: 150}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	g.mu.RLock()
+	index := map[string]int{}
+	nodes := []layoutNode{}
+	for _, node := range sortedNodes(g.nodes) {
+		if !g.nodeVisible(node) || !matchesNode(node, options.filters) {
+			continue
+		}
+		index[node.ID] = len(nodes)
+		nodes = append(nodes, layoutNode{
+			node: node,
+			x:    scatter(node.ID, 0, options.width),
+			y:    scatter(node.ID, 1, options.height),
+		})
+	}
+	edges := []layoutEdge{}
+	for _, rel := range sortedRelationships(g.relationships) {
+		from, fromOK := index[rel.From]
+		to, toOK := index[rel.To]
+		if !fromOK || !toOK {
+			continue
+		}
+		edges = append(edges, layoutEdge{from: from, to: to, label: rel.Label})
+	}
+	g.mu.RUnlock()
+	centerX, centerY := float64(options.width)/2, float64(options.height)/2
+	for iteration := 0; iteration < options.iterations; iteration++ {
+		for i := range nodes {
+			for j := range nodes {
+				if i == j {
+					continue
+				}
+				dx, dy := nodes[i].x-nodes[j].x, nodes[i].y-nodes[j].y
+				d2 := math.Max(dx*dx+dy*dy, 25)
+				force := 1200 / d2
+				d := math.Sqrt(d2)
+				nodes[i].vx += dx / d * force
+				nodes[i].vy += dy / d * force
+			}
+		}
+		for _, edge := range edges {
+			a, b := &nodes[edge.from], &nodes[edge.to]
+			dx, dy := b.x-a.x, b.y-a.y
+			d := math.Max(math.Hypot(dx, dy), 1)
+			force := (d - 90) * 0.02
+			a.vx += dx / d * force
+			a.vy += dy / d * force
+			b.vx -= dx / d * force
+			b.vy -= dy / d * force
+		}
+		for i := range nodes {
+			nodes[i].vx += (centerX - nodes[i].x) * 0.002
+			nodes[i].vy += (centerY - nodes[i].y) * 0.002
+			nodes[i].vx *= 0.85
+			nodes[i].vy *= 0.85
+			nodes[i].x = clamp(nodes[i].x+nodes[i].vx, 10, float64(options.width)-10)
+			nodes[i].y = clamp(nodes[i].y+nodes[i].vy, 10, float64(options.height)-10)
+		}
+	}
+	return options, nodes, edges
+}
+
+// scatter derives a stable pseudo-random coordinate from a node ID, keeping
+// a 10-pixel margin. The usable span is clamped to at least one pixel so tiny
+// render sizes cannot make the modulus zero.
+func scatter(id string, axis uint32, limit int) float64 {
+	h := fnv.New32a()
+	h.Write([]byte(id))
+	seed := h.Sum32() + axis*0x9e3779b9
+	seed ^= seed << 13
+	seed ^= seed >> 17
+	seed ^= seed << 5
+	span := limit - 20
+	if span < 1 {
+		span = 1
+	}
+	return float64(seed%uint32(span)) + 10
+}
+
+// labelColor maps a label to a stable, reasonably distinct RGB color.
+func labelColor(label string) (r, g, b uint8) {
+	h := fnv.New32a()
+	h.Write([]byte(label))
+	hue := float64(h.Sum32()%360) / 60
+	sector := int(hue)
+	fraction := hue - float64(sector)
+	// Fixed saturation/value picked so labels stay readable on white.
+	const value, chroma = 0.85, 0.55
+	low := uint8(math.Round((value - chroma) * 255))
+	high := uint8(math.Round(value * 255))
+	rising := uint8((value - chroma + chroma*fraction) * 255)
+	falling := uint8((value - chroma*fraction) * 255)
+	switch sector {
+	case 0:
+		return high, rising, low
+	case 1:
+		return falling, high, low
+	case 2:
+		return low, high, rising
+	case 3:
+		return low, falling, high
+	case 4:
+		return rising, low, high
+	default:
+		return high, low, falling
+	}
+}
+
+func clamp(v, lo, hi float64) float64 {
+	return math.Min(math.Max(v, lo), hi)
+}
+
+// fill paints the whole image with one color.
+func fill(img *image.RGBA, c color.Color) {
+	bounds := img.Bounds()
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			img.Set(x, y, c)
+		}
+	}
+}
+
+// drawLine plots a line with Bresenham's algorithm.
+func drawLine(img *image.RGBA, x0, y0, x1, y1 int, c color.Color) {
+	dx, dy := abs(x1-x0), -abs(y1-y0)
+	sx, sy := 1, 1
+	if x0 > x1 {
+		sx = -1
+	}
+	if y0 > y1 {
+		sy = -1
+	}
+	err := dx + dy
+	for {
+		img.Set(x0, y0, c)
+		if x0 == x1 && y0 == y1 {
+			return
+		}
+		if 2*err >= dy {
+			err += dy
+			x0 += sx
+		}
+		if 2*err <= dx {
+			err += dx
+			y0 += sy
+		}
+	}
+}
+
+// drawDisc paints a filled circle.
+func drawDisc(img *image.RGBA, cx, cy, radius int, c color.Color) {
+	for y := -radius; y <= radius; y++ {
+		for x := -radius; x <= radius; x++ {
+			if x*x+y*y <= radius*radius {
+				img.Set(cx+x, cy+y, c)
+			}
+		}
+	}
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// xmlEscape quotes the characters with meaning in SVG text content.
+func xmlEscape(s string) string {
+	out := make([]byte, 0, len(s))
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '&':
+			out = append(out, "&amp;"...)
+		case '<':
+			out = append(out, "&lt;"...)
+		case '>':
+			out = append(out, "&gt;"...)
+		case '"':
+			out = append(out, "&quot;"...)
+		default:
+			out = append(out, s[i])
+		}
+	}
+	return string(out)
+}
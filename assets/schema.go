@@ -0,0 +1,130 @@
+package assets
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// jsonSchema is the subset of JSON Schema the registry understands: type,
+// properties, required, items and enum, nested to any depth. It covers the
+// shape-drift problems asset bodies actually have without pulling in a full
+// draft implementation.
+type jsonSchema struct {
+	Type       string                `json:"type"`
+	Properties map[string]jsonSchema `json:"properties"`
+	Required   []string              `json:"required"`
+	Items      *jsonSchema           `json:"items"`
+	Enum       []interface{}         `json:"enum"`
+}
+
+// RegisterSchema registers a JSON Schema for the given label. From then on
+// the bodies of inserted and updated nodes carrying that label are validated
+// against it, and violations fail the mutation with an error wrapping
+// ErrValidation.
+func (g *Graph) RegisterSchema(label string, schema []byte) error {
+	var parsed jsonSchema
+	if err := json.Unmarshal(schema, &parsed); err != nil {
+		return fmt.Errorf("parsing schema for label %q: %w", label, err)
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.schemas == nil {
+		g.schemas = map[string]*jsonSchema{}
+	}
+	g.schemas[label] = &parsed
+	return nil
+}
+
+// validateBody checks a node body against the schema registered for its
+// label, if any. Callers must hold at least a read lock.
+func (g *Graph) validateBody(label string, body []byte) error {
+	schema, ok := g.schemas[label]
+	if !ok {
+		return nil
+	}
+	var value interface{}
+	if len(body) > 0 {
+		if err := json.Unmarshal(body, &value); err != nil {
+			return fmt.Errorf("%w: body of label %q is not valid JSON: %v", ErrValidation, label, err)
+		}
+	}
+	if err := schema.validate(value, "body"); err != nil {
+		return fmt.Errorf("%w: %v", ErrValidation, err)
+	}
+	return nil
+}
+
+func (s *jsonSchema) validate(value interface{}, path string) error {
+	if len(s.Enum) > 0 {
+		matched := false
+		for _, allowed := range s.Enum {
+			if reflect.DeepEqual(value, allowed) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return fmt.Errorf("%s: value not in enum", path)
+		}
+	}
+	switch s.Type {
+	case "":
+		// No type constraint.
+	case "object":
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%s: expected object", path)
+		}
+		for _, name := range s.Required {
+			if _, present := object[name]; !present {
+				return fmt.Errorf("%s: missing required property %q", path, name)
+			}
+		}
+		for name, property := range s.Properties {
+			if child, present := object[name]; present {
+				property := property
+				if err := property.validate(child, path+"."+name); err != nil {
+					return err
+				}
+			}
+		}
+	case "array":
+		items, ok := value.([]interface{})
+		if !ok {
+			return fmt.Errorf("%s: expected array", path)
+		}
+		if s.Items != nil {
+			for i, item := range items {
+				if err := s.Items.validate(item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+					return err
+				}
+			}
+		}
+	case "string":
+		if _, ok := value.(string); !ok {
+			return fmt.Errorf("%s: expected string", path)
+		}
+	case "number":
+		if _, ok := value.(float64); !ok {
+			return fmt.Errorf("%s: expected number", path)
+		}
+	case "integer":
+		number, ok := value.(float64)
+		if !ok || number != math.Trunc(number) {
+			return fmt.Errorf("%s: expected integer", path)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("%s: expected boolean", path)
+		}
+	case "null":
+		if value != nil {
+			return fmt.Errorf("%s: expected null", path)
+		}
+	default:
+		return fmt.Errorf("%s: unknown schema type %q", path, s.Type)
+	}
+	return nil
+}
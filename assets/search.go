@@ -0,0 +1,207 @@
+package assets
+
+import (
+	"encoding/json"
+	"strings"
+	"unicode"
+)
+
+// SearchOption configures Search.
+type SearchOption func(*searchOptions)
+
+type searchOptions struct {
+	prefix bool
+	fuzzy  int
+}
+
+// WithPrefixMatch makes Search match query terms against the beginning of
+// indexed tokens, so "web" finds "webserver-01".
+func WithPrefixMatch() SearchOption {
+	return func(o *searchOptions) {
+		o.prefix = true
+	}
+}
+
+// WithFuzzy makes Search tolerate up to distance single-character edits per
+// term, so "datbase" still finds "database".
+func WithFuzzy(distance int) SearchOption {
+	return func(o *searchOptions) {
+		o.fuzzy = distance
+	}
+}
+
+// searchIndex is an inverted index over the tokens of node names, labels and
+// body string fields, kept in sync by putNode and removeNode.
+type searchIndex struct {
+	byToken map[string]map[string]bool // token -> node IDs
+	tokens  map[string][]string        // node ID -> its tokens
+}
+
+// Search returns the nodes whose name, label or body strings contain every
+// term of the query. Matching is case-insensitive; see WithPrefixMatch and
+// WithFuzzy for looser matching when only part of a hostname is remembered.
+func (g *Graph) Search(query string, opts ...SearchOption) []Node {
+	options := searchOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	terms := tokenize(query)
+	if len(terms) == 0 {
+		return nil
+	}
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	var candidates map[string]bool
+	for _, term := range terms {
+		matched := g.search.lookup(term, options)
+		if candidates == nil {
+			candidates = matched
+			continue
+		}
+		for id := range candidates {
+			if !matched[id] {
+				delete(candidates, id)
+			}
+		}
+	}
+	nodes := make([]Node, 0, len(candidates))
+	for id := range candidates {
+		if node, ok := g.nodes[id]; ok && g.nodeVisible(node) {
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes
+}
+
+// lookup returns the IDs of nodes with a token matching the term under the
+// given options.
+func (i *searchIndex) lookup(term string, options searchOptions) map[string]bool {
+	matched := map[string]bool{}
+	if i == nil {
+		return matched
+	}
+	for id := range i.byToken[term] {
+		matched[id] = true
+	}
+	if !options.prefix && options.fuzzy == 0 {
+		return matched
+	}
+	for token, ids := range i.byToken {
+		if options.prefix && strings.HasPrefix(token, term) ||
+			options.fuzzy > 0 && editDistance(token, term) <= options.fuzzy {
+			for id := range ids {
+				matched[id] = true
+			}
+		}
+	}
+	return matched
+}
+
+// indexNodeText records the node's tokens in the search index. Callers must
+// hold the write lock.
+func (g *Graph) indexNodeText(node Node) {
+	if g.search == nil {
+		g.search = &searchIndex{
+			byToken: map[string]map[string]bool{},
+			tokens:  map[string][]string{},
+		}
+	}
+	g.unindexNodeText(node)
+	tokens := nodeTokens(node)
+	g.search.tokens[node.ID] = tokens
+	for _, token := range tokens {
+		if g.search.byToken[token] == nil {
+			g.search.byToken[token] = map[string]bool{}
+		}
+		g.search.byToken[token][node.ID] = true
+	}
+}
+
+// unindexNodeText removes the node's tokens from the search index. Callers
+// must hold the write lock.
+func (g *Graph) unindexNodeText(node Node) {
+	if g.search == nil {
+		return
+	}
+	for _, token := range g.search.tokens[node.ID] {
+		delete(g.search.byToken[token], node.ID)
+		if len(g.search.byToken[token]) == 0 {
+			delete(g.search.byToken, token)
+		}
+	}
+	delete(g.search.tokens, node.ID)
+}
+
+// nodeTokens gathers the searchable tokens of a node: its name, label and
+// every string value found in its JSON body.
+func nodeTokens(node Node) []string {
+	seen := map[string]bool{}
+	var tokens []string
+	collect := func(text string) {
+		for _, token := range tokenize(text) {
+			if !seen[token] {
+				seen[token] = true
+				tokens = append(tokens, token)
+			}
+		}
+	}
+	collect(node.Name)
+	collect(node.Label)
+	if len(node.Body) > 0 {
+		var value any
+		if err := json.Unmarshal(node.Body, &value); err == nil {
+			collectStrings(value, collect)
+		}
+	}
+	for _, value := range node.Properties {
+		if text, ok := value.(string); ok {
+			collect(text)
+		}
+	}
+	return tokens
+}
+
+// collectStrings walks decoded JSON and feeds every string value to collect.
+func collectStrings(value any, collect func(string)) {
+	switch v := value.(type) {
+	case string:
+		collect(v)
+	case map[string]any:
+		for _, child := range v {
+			collectStrings(child, collect)
+		}
+	case []any:
+		for _, child := range v {
+			collectStrings(child, collect)
+		}
+	}
+}
+
+// tokenize lowercases text and splits it on anything that is not a letter or
+// digit.
+func tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// editDistance is the Levenshtein distance between two tokens.
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, min(current[j-1]+1, previous[j-1]+cost))
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
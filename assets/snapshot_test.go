@@ -0,0 +1,68 @@
+package assets
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestBinarySnapshotRoundTripsProperties(t *testing.T) {
+	g := NewGraph()
+	props := map[string]any{
+		"tags":   []any{"prod", "eu-west-1"},
+		"limits": map[string]any{"cpu": float64(4), "burst": true},
+	}
+	node, err := g.AddNode("web-01", "server", []byte(`{"cpu":4}`), WithProperties(props))
+	if err != nil {
+		t.Fatalf("AddNode: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := g.Save(&buf, WithFormat(FormatBinary)); err != nil {
+		t.Fatalf("Save binary: %v", err)
+	}
+	loaded, err := Load(&buf)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	got, err := loaded.GetNodeByID(node.ID)
+	if err != nil {
+		t.Fatalf("GetNodeByID after load: %v", err)
+	}
+	if !reflect.DeepEqual(got.Properties, props) {
+		t.Errorf("properties after round trip = %#v, want %#v", got.Properties, props)
+	}
+}
+
+func TestCompactWithProperties(t *testing.T) {
+	dir := t.TempDir()
+	g, err := OpenGraph(dir)
+	if err != nil {
+		t.Fatalf("OpenGraph: %v", err)
+	}
+	node, err := g.AddNode("web-01", "server", nil,
+		WithProperties(map[string]any{"tags": []any{"prod"}}))
+	if err != nil {
+		t.Fatalf("AddNode: %v", err)
+	}
+	// Compaction writes a binary snapshot, so it must survive structured
+	// properties too.
+	if err := g.Compact(); err != nil {
+		t.Fatalf("Compact: %v", err)
+	}
+	if err := g.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	reopened, err := OpenGraph(dir)
+	if err != nil {
+		t.Fatalf("reopening compacted graph: %v", err)
+	}
+	defer reopened.Close()
+	got, err := reopened.GetNodeByID(node.ID)
+	if err != nil {
+		t.Fatalf("GetNodeByID after compaction: %v", err)
+	}
+	if !reflect.DeepEqual(got.Properties, map[string]any{"tags": []any{"prod"}}) {
+		t.Errorf("properties after compaction = %#v", got.Properties)
+	}
+}
@@ -0,0 +1,64 @@
+package assets
+
+import "reflect"
+
+// WithSoftDelete makes DeleteNode tombstone nodes instead of removing them.
+// Soft-deleted nodes keep their relationships but disappear from lookups,
+// listings and traversals until restored with RestoreNode; pass
+// IncludeDeleted to ListNodes to see them.
+func WithSoftDelete() GraphOption {
+	return func(g *Graph) {
+		g.softDelete = true
+	}
+}
+
+// IncludeDeleted makes ListNodes return soft-deleted nodes alongside live
+// ones.
+func IncludeDeleted() Filter {
+	return includeDeletedMarker
+}
+
+// includeDeletedMarker is recognized by pointer identity when ListNodes
+// splits its filters.
+var includeDeletedMarker Filter = func(Node) bool { return true }
+
+// splitIncludeDeleted strips the IncludeDeleted marker from a filter list
+// and reports whether it was present.
+func splitIncludeDeleted(filters []Filter) (bool, []Filter) {
+	marker := reflect.ValueOf(includeDeletedMarker).Pointer()
+	include := false
+	kept := make([]Filter, 0, len(filters))
+	for _, f := range filters {
+		if reflect.ValueOf(f).Pointer() == marker {
+			include = true
+			continue
+		}
+		kept = append(kept, f)
+	}
+	return include, kept
+}
+
+// RestoreNode clears the tombstone of a soft-deleted node, making it visible
+// again. ErrNotFound is returned when the node does not exist or is not
+// deleted.
+func (g *Graph) RestoreNode(id string) (Node, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	node, ok := g.nodes[id]
+	if !ok || !node.isDeleted() {
+		return Node{}, ErrNotFound
+	}
+	node.DeletedAt = nil
+	node.UpdatedAt = now()
+	g.putNode(node)
+	if err := g.logMutation(walEntry{Op: opUpdateNode, Node: &node}); err != nil {
+		return Node{}, err
+	}
+	g.emitAfter(func() { runAfter(g.hooks.afterUpdateNode, node) })
+	return node, nil
+}
+
+// isDeleted reports whether the node carries a soft-delete tombstone.
+func (n Node) isDeleted() bool {
+	return n.DeletedAt != nil
+}
@@ -0,0 +1,72 @@
+package assets
+
+// Stats aggregates the shape of the graph for dashboards and capacity
+// checks. See Graph.Stats.
+type Stats struct {
+	Nodes                 int            `json:"nodes"`
+	Relationships         int            `json:"relationships"`
+	NodesPerLabel         map[string]int `json:"nodes_per_label"`
+	RelationshipsPerLabel map[string]int `json:"relationships_per_label"`
+	// DegreeDistribution maps total degree (in plus out) to the number of
+	// nodes with that degree.
+	DegreeDistribution map[int]int `json:"degree_distribution"`
+	// IsolatedNodes counts nodes with no relationships at all.
+	IsolatedNodes int `json:"isolated_nodes"`
+}
+
+// Degree returns the number of incoming and outgoing relationships of the
+// node with the given ID. It reads the adjacency indexes, so its cost is
+// proportional to the degree itself.
+func (g *Graph) Degree(nodeID string) (in, out int) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	cutoff := now()
+	for relID := range g.relsByTo[nodeID] {
+		if !g.relationships[relID].expiredAt(cutoff) {
+			in++
+		}
+	}
+	for relID := range g.relsByFrom[nodeID] {
+		if !g.relationships[relID].expiredAt(cutoff) {
+			out++
+		}
+	}
+	return in, out
+}
+
+// Stats returns node and relationship counts per label, the degree
+// distribution and the number of isolated nodes, computed in a single pass
+// under the read lock.
+func (g *Graph) Stats() Stats {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	stats := Stats{
+		NodesPerLabel:         map[string]int{},
+		RelationshipsPerLabel: map[string]int{},
+		DegreeDistribution:    map[int]int{},
+	}
+	cutoff := now()
+	degrees := map[string]int{}
+	for _, rel := range g.relationships {
+		if rel.expiredAt(cutoff) {
+			continue
+		}
+		stats.Relationships++
+		stats.RelationshipsPerLabel[rel.Label]++
+		degrees[rel.From]++
+		degrees[rel.To]++
+	}
+	for id, node := range g.nodes {
+		if !g.nodeVisible(node) {
+			continue
+		}
+		stats.Nodes++
+		stats.NodesPerLabel[node.Label]++
+		degree := degrees[id]
+		stats.DegreeDistribution[degree]++
+		if degree == 0 {
+			stats.IsolatedNodes++
+		}
+	}
+	return stats
+}
@@ -0,0 +1,54 @@
+package assets
+
+// Subgraph returns a new graph containing the node with the given ID,
+// everything reachable from it within depth hops, and the relationships
+// connecting the included nodes. A negative depth means unlimited. Traversal
+// options control the direction and the relationships followed; IDs are
+// preserved, so the result can be diffed or merged against the original.
+// ErrNotFound is returned when the root node does not exist.
+func (g *Graph) Subgraph(rootID string, depth int, opts ...TraversalOption) (*Graph, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	root, err := g.getNodeByID(rootID)
+	if err != nil {
+		return nil, err
+	}
+	options := newTraversalOptions(opts)
+	subgraph := NewGraph()
+	subgraph.putNode(root)
+	frontier := []string{rootID}
+	for hop := 0; len(frontier) > 0 && (depth < 0 || hop < depth); hop++ {
+		next := []string{}
+		for _, id := range frontier {
+			for _, e := range g.edgesFrom(id, options) {
+				node, err := g.getNodeByID(e.to)
+				if err != nil {
+					continue
+				}
+				if _, ok := subgraph.nodes[node.ID]; ok {
+					continue
+				}
+				subgraph.putNode(node)
+				next = append(next, node.ID)
+			}
+		}
+		frontier = next
+	}
+	cutoff := now()
+	for _, rel := range g.relationships {
+		if rel.expiredAt(cutoff) {
+			continue
+		}
+		if !matchesRelationship(rel, options.relFilters) {
+			continue
+		}
+		if _, ok := subgraph.nodes[rel.From]; !ok {
+			continue
+		}
+		if _, ok := subgraph.nodes[rel.To]; !ok {
+			continue
+		}
+		subgraph.putRelationship(rel)
+	}
+	return subgraph, nil
+}
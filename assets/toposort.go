@@ -0,0 +1,77 @@
+package assets
+
+import "sort"
+
+// TopologicalSort returns the visible nodes ordered so that every
+// relationship points from an earlier node to a later one, yielding e.g. a
+// teardown order straight from the dependency edges. With labels given, only
+// relationships carrying one of those labels constrain the order. ErrCycle
+// is returned when the considered edges contain a cycle. Ties are broken by
+// node ID so the ordering is deterministic.
+func (g *Graph) TopologicalSort(relLabels ...string) ([]Node, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	considered := func(rel Relationship) bool {
+		if len(relLabels) == 0 {
+			return true
+		}
+		for _, label := range relLabels {
+			if rel.Label == label {
+				return true
+			}
+		}
+		return false
+	}
+	cutoff := now()
+	inDegree := map[string]int{}
+	for _, node := range g.nodes {
+		if g.nodeVisible(node) {
+			inDegree[node.ID] = 0
+		}
+	}
+	for _, rel := range g.relationships {
+		if rel.expiredAt(cutoff) || !considered(rel) {
+			continue
+		}
+		if _, fromOK := inDegree[rel.From]; !fromOK {
+			continue
+		}
+		if _, toOK := inDegree[rel.To]; !toOK {
+			continue
+		}
+		inDegree[rel.To]++
+	}
+	ready := []string{}
+	for id, degree := range inDegree {
+		if degree == 0 {
+			ready = append(ready, id)
+		}
+	}
+	sort.Strings(ready)
+	ordered := make([]Node, 0, len(inDegree))
+	for len(ready) > 0 {
+		id := ready[0]
+		ready = ready[1:]
+		ordered = append(ordered, g.nodes[id])
+		released := []string{}
+		for relID := range g.relsByFrom[id] {
+			rel := g.relationships[relID]
+			if rel.expiredAt(cutoff) || !considered(rel) {
+				continue
+			}
+			if _, ok := inDegree[rel.To]; !ok {
+				continue
+			}
+			inDegree[rel.To]--
+			if inDegree[rel.To] == 0 {
+				released = append(released, rel.To)
+			}
+		}
+		sort.Strings(released)
+		ready = append(ready, released...)
+	}
+	if len(ordered) != len(inDegree) {
+		return nil, ErrCycle
+	}
+	return ordered, nil
+}
@@ -0,0 +1,120 @@
+package assets
+
+import "time"
+
+// Direction selects which way relationships are followed during a traversal.
+type Direction int
+
+const (
+	// Outgoing follows relationships from their From node to their To node.
+	Outgoing Direction = iota
+	// Incoming follows relationships in reverse, from To to From.
+	Incoming
+	// Undirected follows relationships in both directions.
+	Undirected
+)
+
+// TraversalOption configures graph traversal operations such as Neighbors.
+type TraversalOption func(*traversalOptions)
+
+type traversalOptions struct {
+	direction     Direction
+	relFilters    []FilterRelationship
+	throughLabels map[string]bool
+	avoidLabels   map[string]bool
+}
+
+// WithDirection sets the direction in which relationships are followed. The
+// default is Outgoing.
+func WithDirection(direction Direction) TraversalOption {
+	return func(o *traversalOptions) {
+		o.direction = direction
+	}
+}
+
+// WithRelFilters restricts a traversal to relationships matching all of the
+// given filters.
+func WithRelFilters(filters ...FilterRelationship) TraversalOption {
+	return func(o *traversalOptions) {
+		o.relFilters = append(o.relFilters, filters...)
+	}
+}
+
+// WithRelationshipLabels restricts a traversal to relationships carrying one
+// of the given labels, so e.g. a dependency walk does not wander across
+// ownership edges:
+//
+//	g.ListConnections(start, assets.WithRelationshipLabels("depends_on", "hosts"))
+func WithRelationshipLabels(labels ...string) TraversalOption {
+	labelFilters := make([]FilterRelationship, 0, len(labels))
+	for _, label := range labels {
+		labelFilters = append(labelFilters, FilterRelByLabel(label))
+	}
+	return WithRelFilters(OrRel(labelFilters...))
+}
+
+// ThroughLabels restricts a traversal to stepping only onto nodes carrying
+// one of the given labels. The node a traversal starts from is exempt.
+func ThroughLabels(labels ...string) TraversalOption {
+	return func(o *traversalOptions) {
+		if o.throughLabels == nil {
+			o.throughLabels = map[string]bool{}
+		}
+		for _, label := range labels {
+			o.throughLabels[label] = true
+		}
+	}
+}
+
+// AvoidLabels keeps a traversal from stepping onto nodes carrying any of the
+// given labels — typically super-nodes like a datacenter that connect
+// everything to everything. The node a traversal starts from is exempt.
+func AvoidLabels(labels ...string) TraversalOption {
+	return func(o *traversalOptions) {
+		if o.avoidLabels == nil {
+			o.avoidLabels = map[string]bool{}
+		}
+		for _, label := range labels {
+			o.avoidLabels[label] = true
+		}
+	}
+}
+
+// ActiveAt restricts a traversal to relationships whose validity window
+// contained the given time, reconstructing the topology as it stood then.
+// Relationships without validity bounds are always followed.
+func ActiveAt(t time.Time) TraversalOption {
+	return WithRelFilters(FilterRelsActiveAt(t))
+}
+
+func newTraversalOptions(opts []TraversalOption) traversalOptions {
+	options := traversalOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
+}
+
+// Neighbors returns the nodes directly connected to the node with the given
+// ID. By default only outgoing relationships are followed; use WithDirection
+// and WithRelFilters to change the direction or restrict the edges
+// considered. Each neighbor appears at most once.
+func (g *Graph) Neighbors(nodeID string, opts ...TraversalOption) []Node {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	options := newTraversalOptions(opts)
+	seen := map[string]bool{}
+	neighbors := []Node{}
+	for _, e := range g.edgesFrom(nodeID, options) {
+		if seen[e.to] {
+			continue
+		}
+		node, err := g.getNodeByID(e.to)
+		if err != nil {
+			continue
+		}
+		seen[e.to] = true
+		neighbors = append(neighbors, node)
+	}
+	return neighbors
+}
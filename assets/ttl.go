@@ -0,0 +1,108 @@
+package assets
+
+import (
+	"log/slog"
+	"time"
+)
+
+// NodeOption configures a node as it is added to the graph.
+type NodeOption func(*Node)
+
+// WithTTL gives the node a time-to-live; once it elapses the node behaves as
+// if it were deleted and is removed by the reaper. See StartReaper.
+func WithTTL(d time.Duration) NodeOption {
+	return func(n *Node) {
+		expiry := n.CreatedAt.Add(d)
+		n.ExpiresAt = &expiry
+	}
+}
+
+// WithRelTTL gives the relationship a time-to-live; once it elapses the
+// relationship is no longer followed and is removed by the reaper.
+func WithRelTTL(d time.Duration) RelOption {
+	return func(r *Relationship) {
+		expiry := r.CreatedAt.Add(d)
+		r.ExpiresAt = &expiry
+	}
+}
+
+// OnExpired registers a hook fired for every node the reaper removes because
+// its TTL elapsed.
+func (g *Graph) OnExpired(fn func(Node)) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.hooks.expired = append(g.hooks.expired, fn)
+}
+
+// StartReaper starts a goroutine that removes expired nodes and
+// relationships every interval, firing the Expired event for each removed
+// node. The returned function stops the reaper. Expired elements are hidden
+// from reads as soon as their TTL elapses, so the reaper only bounds memory,
+// not visibility.
+func (g *Graph) StartReaper(interval time.Duration) (stop func()) {
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				g.reap()
+			}
+		}
+	}()
+	return func() { close(done) }
+}
+
+// reap removes every expired node and relationship. The write-ahead log is
+// appended before each in-memory removal; when the append fails the removal
+// is skipped and retried on the next tick, so disk and memory never diverge.
+// The reaper cannot return the error, so failures are surfaced through the
+// configured logger.
+func (g *Graph) reap() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	cutoff := now()
+	for _, rel := range g.listRelationships(nil) {
+		if !rel.expiredAt(cutoff) {
+			continue
+		}
+		if err := g.logMutation(walEntry{Op: opDeleteRelationship, ID: rel.ID}); err != nil {
+			g.logEvent(slog.LevelError, "reap skipped", "relationship", rel.ID, "error", err)
+			continue
+		}
+		g.removeRelationship(rel)
+	}
+	for id, node := range g.nodes {
+		if !node.expiredAt(cutoff) {
+			continue
+		}
+		if err := g.logMutation(walEntry{Op: opDeleteNode, ID: id}); err != nil {
+			g.logEvent(slog.LevelError, "reap skipped", "node", id, "error", err)
+			continue
+		}
+		for relID := range g.relsByFrom[id] {
+			g.removeRelationship(g.relationships[relID])
+		}
+		for relID := range g.relsByTo[id] {
+			g.removeRelationship(g.relationships[relID])
+		}
+		g.removeNode(node)
+		delete(g.history, id)
+		node := node
+		g.emitAfter(func() { runAfter(g.hooks.expired, node) })
+	}
+}
+
+// expiredAt reports whether the node's TTL had elapsed at the given time.
+func (n Node) expiredAt(t time.Time) bool {
+	return n.ExpiresAt != nil && !n.ExpiresAt.After(t)
+}
+
+// expiredAt reports whether the relationship's TTL had elapsed at the given
+// time.
+func (r Relationship) expiredAt(t time.Time) bool {
+	return r.ExpiresAt != nil && !r.ExpiresAt.After(t)
+}
@@ -0,0 +1,171 @@
+package assets
+
+// Txn is the handle passed to the closure of Tx. Its methods mirror the
+// mutating methods of Graph but take effect atomically: either every
+// operation of the transaction is applied, or none is.
+type Txn struct {
+	graph *Graph
+}
+
+// Tx runs the given closure as a single atomic transaction. The closure can
+// insert, update and delete nodes and relationships through the transaction
+// handle; when it returns nil every change is kept, and when it returns an
+// error the graph is restored to its state before the transaction. Write-
+// ahead log entries and after-hooks are held back until the transaction
+// commits. The graph lock is held for the duration of the closure, so the
+// closure must use the transaction handle rather than the graph itself.
+func (g *Graph) Tx(fn func(tx *Txn) error) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	backup := g.copyState()
+	pending := []walEntry{}
+	deferred := []func(){}
+	g.pendingWAL = &pending
+	g.deferredAfter = &deferred
+	err := fn(&Txn{graph: g})
+	g.pendingWAL = nil
+	g.deferredAfter = nil
+	if err != nil {
+		g.restoreState(backup)
+		g.rebuildDerivedIndexes()
+		return err
+	}
+	for _, entry := range pending {
+		if err := g.logMutation(entry); err != nil {
+			g.restoreState(backup)
+			g.rebuildDerivedIndexes()
+			return err
+		}
+	}
+	for _, hook := range deferred {
+		hook()
+	}
+	return nil
+}
+
+// InsertNode adds a node within the transaction.
+func (t *Txn) InsertNode(name, label string, body []byte, opts ...NodeOption) (Node, error) {
+	return t.graph.addNode(name, label, body, opts)
+}
+
+// UpdateNode updates a node's body within the transaction.
+func (t *Txn) UpdateNode(id string, body []byte) (Node, error) {
+	return t.graph.updateNode(id, body)
+}
+
+// DeleteNode deletes a node within the transaction.
+func (t *Txn) DeleteNode(id string, opts ...DeleteOption) error {
+	return t.graph.deleteNode(id, opts)
+}
+
+// AddRelationship adds a relationship within the transaction.
+func (t *Txn) AddRelationship(from, to Node, label string, opts ...RelOption) (Relationship, error) {
+	return t.graph.addRelationship(from, to, label, opts)
+}
+
+// UpdateRelationship updates a relationship within the transaction.
+func (t *Txn) UpdateRelationship(id string, opts ...RelUpdateOption) (Relationship, error) {
+	return t.graph.updateRelationship(id, opts)
+}
+
+// DeleteRelationship deletes a relationship within the transaction.
+func (t *Txn) DeleteRelationship(id string) error {
+	return t.graph.deleteRelationship(id)
+}
+
+// GetNodeByID returns a node as the transaction currently sees it.
+func (t *Txn) GetNodeByID(id string) (Node, error) {
+	return t.graph.getNodeByID(id)
+}
+
+// emitAfter runs an after-hook immediately, or defers it until commit when a
+// transaction is open.
+func (g *Graph) emitAfter(fn func()) {
+	if g.deferredAfter != nil {
+		*g.deferredAfter = append(*g.deferredAfter, fn)
+		return
+	}
+	fn()
+}
+
+// graphState is a deep copy of the mutable state of a graph, used to roll
+// back failed transactions.
+type graphState struct {
+	nodes         map[string]Node
+	relationships map[string]Relationship
+	relsByFrom    map[string]map[string]bool
+	relsByTo      map[string]map[string]bool
+	nodesByLabel  map[string]map[string]bool
+	nodesByName   map[string]map[string]bool
+	history       map[string][]NodeRevision
+}
+
+// copyState captures the graph's current state. Callers must hold the write
+// lock.
+func (g *Graph) copyState() graphState {
+	state := graphState{
+		nodes:         make(map[string]Node, len(g.nodes)),
+		relationships: make(map[string]Relationship, len(g.relationships)),
+	}
+	for id, node := range g.nodes {
+		state.nodes[id] = node
+	}
+	for id, rel := range g.relationships {
+		state.relationships[id] = rel
+	}
+	state.relsByFrom = copyIndex(g.relsByFrom)
+	state.relsByTo = copyIndex(g.relsByTo)
+	state.nodesByLabel = copyIndex(g.nodesByLabel)
+	state.nodesByName = copyIndex(g.nodesByName)
+	state.history = make(map[string][]NodeRevision, len(g.history))
+	for id, revisions := range g.history {
+		copied := make([]NodeRevision, len(revisions))
+		copy(copied, revisions)
+		state.history[id] = copied
+	}
+	return state
+}
+
+// restoreState puts back a previously captured state. Callers must hold the
+// write lock.
+func (g *Graph) restoreState(state graphState) {
+	g.bumpEpoch()
+	g.nodes = state.nodes
+	g.relationships = state.relationships
+	g.relsByFrom = state.relsByFrom
+	g.relsByTo = state.relsByTo
+	g.nodesByLabel = state.nodesByLabel
+	g.nodesByName = state.nodesByName
+	g.history = state.history
+}
+
+// rebuildDerivedIndexes recomputes the body-field and search indexes from
+// the node set. graphState deliberately omits them — they can be large and
+// rollbacks are rare — so after restoreState they still reflect the
+// transaction's discarded writes and must be rebuilt. Callers must hold the
+// write lock.
+func (g *Graph) rebuildDerivedIndexes() {
+	for key := range g.bodyIndexes {
+		g.bodyIndexes[key] = &bodyIndex{
+			values:  map[string]any{},
+			byValue: map[string]map[string]bool{},
+		}
+	}
+	g.search = nil
+	for _, node := range g.nodes {
+		g.indexNode(node)
+		g.indexNodeText(node)
+	}
+}
+
+func copyIndex(index map[string]map[string]bool) map[string]map[string]bool {
+	copied := make(map[string]map[string]bool, len(index))
+	for key, ids := range index {
+		entry := make(map[string]bool, len(ids))
+		for id := range ids {
+			entry[id] = true
+		}
+		copied[key] = entry
+	}
+	return copied
+}
@@ -0,0 +1,99 @@
+package assets
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTxCommit(t *testing.T) {
+	g := NewGraph()
+	var inserted Node
+	err := g.Tx(func(tx *Txn) error {
+		var err error
+		inserted, err = tx.InsertNode("web-01", "server", nil)
+		if err != nil {
+			return err
+		}
+		vpc, err := tx.InsertNode("vpc-1", "network", nil)
+		if err != nil {
+			return err
+		}
+		_, err = tx.AddRelationship(inserted, vpc, "in-network")
+		return err
+	})
+	if err != nil {
+		t.Fatalf("Tx: %v", err)
+	}
+	if _, err := g.GetNodeByID(inserted.ID); err != nil {
+		t.Errorf("committed node not readable: %v", err)
+	}
+	if rels := g.ListRelationships(); len(rels) != 1 {
+		t.Errorf("committed %d relationships, want 1", len(rels))
+	}
+}
+
+func TestTxRollback(t *testing.T) {
+	g := NewGraph()
+	existing, _ := g.AddNode("web-01", "server", []byte(`{"cpu":4}`))
+	boom := errors.New("boom")
+	err := g.Tx(func(tx *Txn) error {
+		if _, err := tx.InsertNode("web-02", "server", nil); err != nil {
+			return err
+		}
+		if _, err := tx.UpdateNode(existing.ID, []byte(`{"cpu":16}`)); err != nil {
+			return err
+		}
+		return boom
+	})
+	if !errors.Is(err, boom) {
+		t.Fatalf("Tx returned %v, want the closure's error", err)
+	}
+	if nodes := g.ListNodes(); len(nodes) != 1 {
+		t.Fatalf("rollback left %d nodes, want 1", len(nodes))
+	}
+	node, err := g.GetNodeByID(existing.ID)
+	if err != nil {
+		t.Fatalf("GetNodeByID after rollback: %v", err)
+	}
+	if string(node.Body) != `{"cpu":4}` {
+		t.Errorf("rollback left body %s, want the pre-transaction body", node.Body)
+	}
+}
+
+func TestTxRollbackRebuildsDerivedIndexes(t *testing.T) {
+	g := NewGraph()
+	g.CreateIndex("server", "cpu")
+	existing, _ := g.AddNode("web-01", "server", []byte(`{"cpu":4}`))
+	boom := errors.New("boom")
+	err := g.Tx(func(tx *Txn) error {
+		if _, err := tx.UpdateNode(existing.ID, []byte(`{"cpu":16}`)); err != nil {
+			return err
+		}
+		if _, err := tx.InsertNode("ghost-01", "server", []byte(`{"cpu":32}`)); err != nil {
+			return err
+		}
+		return boom
+	})
+	if !errors.Is(err, boom) {
+		t.Fatalf("Tx returned %v, want the closure's error", err)
+	}
+	// The body index must reflect the restored state, not the rolled-back
+	// writes.
+	nodes, err := g.FindNodesWhere("server", "cpu", OpEq, float64(4))
+	if err != nil {
+		t.Fatalf("FindNodesWhere: %v", err)
+	}
+	if len(nodes) != 1 || nodes[0].ID != existing.ID {
+		t.Errorf("index query after rollback returned %+v, want the original node", nodes)
+	}
+	if nodes, _ := g.FindNodesWhere("server", "cpu", OpGt, float64(4)); len(nodes) != 0 {
+		t.Errorf("index still holds rolled-back values: %+v", nodes)
+	}
+	// Likewise the search index must not surface nodes the rollback removed.
+	if matches := g.Search("ghost-01"); len(matches) != 0 {
+		t.Errorf("search found rolled-back node: %+v", matches)
+	}
+	if matches := g.Search("web-01"); len(matches) != 1 {
+		t.Errorf("search after rollback returned %d matches for surviving node, want 1", len(matches))
+	}
+}
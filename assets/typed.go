@@ -0,0 +1,27 @@
+package assets
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Body unmarshals a node's JSON body into T, saving callers the
+// json.Unmarshal boilerplate that otherwise appears in every filter and
+// handler.
+func Body[T any](node Node) (T, error) {
+	var value T
+	if err := json.Unmarshal(node.Body, &value); err != nil {
+		return value, fmt.Errorf("unmarshalling body of node %s: %w", node.ID, err)
+	}
+	return value, nil
+}
+
+// InsertTyped marshals v to JSON and inserts it as the body of a new node.
+// It is the write-side counterpart of Body.
+func InsertTyped[T any](g *Graph, name, label string, v T, opts ...NodeOption) (Node, error) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return Node{}, fmt.Errorf("marshalling body for node %q: %w", name, err)
+	}
+	return g.AddNode(name, label, body, opts...)
+}
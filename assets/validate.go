@@ -0,0 +1,79 @@
+package assets
+
+import "fmt"
+
+// IssueKind classifies a problem found by Validate.
+type IssueKind string
+
+const (
+	// IssueDanglingRelationship marks a relationship whose From or To node
+	// no longer exists. Traversals silently skip such edges.
+	IssueDanglingRelationship IssueKind = "dangling_relationship"
+	// IssueIsolatedNode marks a node with no relationships at all.
+	IssueIsolatedNode IssueKind = "isolated_node"
+	// IssueConstraintViolation marks an existing element that breaks a
+	// constraint declared after it was written, such as the relationship
+	// model or a registered schema.
+	IssueConstraintViolation IssueKind = "constraint_violation"
+)
+
+// Issue is one problem found by Validate. NodeID or RelationshipID names the
+// offending element, depending on the kind.
+type Issue struct {
+	Kind           IssueKind `json:"kind"`
+	NodeID         string    `json:"node_id,omitempty"`
+	RelationshipID string    `json:"relationship_id,omitempty"`
+	Message        string    `json:"message"`
+}
+
+// Validate sweeps the graph and reports relationships pointing at missing
+// nodes, isolated nodes, and existing elements that violate the declared
+// relationship model or registered schemas. A healthy graph yields an empty
+// slice.
+func (g *Graph) Validate() []Issue {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	issues := []Issue{}
+	connected := map[string]bool{}
+	for id, rel := range g.relationships {
+		fromNode, fromErr := g.getNodeByID(rel.From)
+		toNode, toErr := g.getNodeByID(rel.To)
+		if fromErr != nil || toErr != nil {
+			issues = append(issues, Issue{
+				Kind:           IssueDanglingRelationship,
+				RelationshipID: id,
+				Message:        fmt.Sprintf("relationship %s references a missing node", id),
+			})
+			continue
+		}
+		connected[rel.From] = true
+		connected[rel.To] = true
+		if err := g.checkRelationshipAllowed(fromNode, toNode, rel.Label); err != nil {
+			issues = append(issues, Issue{
+				Kind:           IssueConstraintViolation,
+				RelationshipID: id,
+				Message:        err.Error(),
+			})
+		}
+	}
+	for id, node := range g.nodes {
+		if !g.nodeVisible(node) {
+			continue
+		}
+		if !connected[id] {
+			issues = append(issues, Issue{
+				Kind:    IssueIsolatedNode,
+				NodeID:  id,
+				Message: fmt.Sprintf("node %q (%s) has no relationships", node.Name, id),
+			})
+		}
+		if err := g.validateBody(node.Label, node.Body); err != nil {
+			issues = append(issues, Issue{
+				Kind:    IssueConstraintViolation,
+				NodeID:  id,
+				Message: err.Error(),
+			})
+		}
+	}
+	return issues
+}
@@ -0,0 +1,257 @@
+package assets
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+)
+
+// File names used inside a persistent graph directory.
+const (
+	walFileName      = "wal.log"
+	snapshotFileName = "snapshot"
+)
+
+// Operations recorded in the write-ahead log.
+const (
+	opInsertNode         = "insert_node"
+	opUpdateNode         = "update_node"
+	opDeleteNode         = "delete_node"
+	opAddRelationship    = "add_relationship"
+	opUpdateRelationship = "update_relationship"
+	opDeleteRelationship = "delete_relationship"
+)
+
+// walEntry is one mutation recorded in the write-ahead log.
+type walEntry struct {
+	Op           string        `json:"op"`
+	ID           string        `json:"id,omitempty"`
+	Node         *Node         `json:"node,omitempty"`
+	Relationship *Relationship `json:"relationship,omitempty"`
+}
+
+// wal is an append-only operation log backing a persistent graph.
+type wal struct {
+	dir     string
+	file    *os.File
+	encoder *json.Encoder
+	writes  int
+	// compactEvery triggers automatic compaction once this many entries
+	// have been appended since the last compaction; zero disables it.
+	compactEvery int
+}
+
+// WALOption configures a graph opened with OpenGraph.
+type WALOption func(*wal)
+
+// WithAutoCompact makes the graph fold the log into a fresh snapshot
+// automatically after every n logged mutations. Compaction can still be
+// triggered manually with Compact.
+func WithAutoCompact(n int) WALOption {
+	return func(w *wal) {
+		w.compactEvery = n
+	}
+}
+
+// OpenGraph opens a graph persisted in the given directory, creating the
+// directory when needed. The graph is rebuilt from the latest snapshot plus
+// a replay of the write-ahead log, and every subsequent mutation is appended
+// to the log before it is acknowledged, so the graph survives crashes
+// without losing writes. Call Close when done and Compact to fold the log
+// into a fresh snapshot.
+func OpenGraph(dir string, opts ...WALOption) (*Graph, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	graph := NewGraph()
+	if err := graph.loadSnapshotFile(filepath.Join(dir, snapshotFileName)); err != nil {
+		return nil, err
+	}
+	if err := graph.replayWAL(filepath.Join(dir, walFileName)); err != nil {
+		return nil, err
+	}
+	file, err := os.OpenFile(filepath.Join(dir, walFileName), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	graph.wal = &wal{dir: dir, file: file, encoder: json.NewEncoder(file)}
+	for _, opt := range opts {
+		opt(graph.wal)
+	}
+	return graph, nil
+}
+
+// Compact writes the current graph to a fresh snapshot and truncates the
+// write-ahead log, bounding replay time on the next open. It is a no-op for
+// graphs not opened with OpenGraph.
+func (g *Graph) Compact() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.compact()
+}
+
+func (g *Graph) compact() error {
+	if g.wal == nil {
+		return nil
+	}
+	path := filepath.Join(g.wal.dir, snapshotFileName)
+	tmp, err := os.CreateTemp(g.wal.dir, snapshotFileName+".tmp")
+	if err != nil {
+		return err
+	}
+	if err := g.saveLocked(tmp, saveOptions{format: FormatBinary}); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := g.wal.file.Truncate(0); err != nil {
+		return err
+	}
+	if _, err := g.wal.file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	g.wal.writes = 0
+	return nil
+}
+
+// Close flushes and closes the write-ahead log. It is a no-op for graphs not
+// opened with OpenGraph.
+func (g *Graph) Close() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.wal == nil {
+		return nil
+	}
+	return g.wal.file.Close()
+}
+
+// logMutation appends a mutation to the write-ahead log when one is attached
+// and triggers automatic compaction when configured. Callers must hold the
+// write lock.
+func (g *Graph) logMutation(entry walEntry) error {
+	if g.pendingWAL != nil {
+		*g.pendingWAL = append(*g.pendingWAL, entry)
+		return nil
+	}
+	g.recordAudit(entry)
+	g.logEvent(slog.LevelDebug, "graph mutation",
+		"op", entry.Op, "node_id", auditNodeID(entry), "relationship_id", auditRelID(entry))
+	if g.wal == nil {
+		return nil
+	}
+	if err := g.wal.encoder.Encode(entry); err != nil {
+		return fmt.Errorf("writing wal: %w", err)
+	}
+	g.wal.writes++
+	if g.wal.compactEvery > 0 && g.wal.writes >= g.wal.compactEvery {
+		return g.compact()
+	}
+	return nil
+}
+
+// auditNodeID extracts the node ID a WAL entry refers to, if any.
+func auditNodeID(entry walEntry) string {
+	if entry.Node != nil {
+		return entry.Node.ID
+	}
+	if entry.Relationship == nil {
+		return entry.ID
+	}
+	return ""
+}
+
+// auditRelID extracts the relationship ID a WAL entry refers to, if any.
+func auditRelID(entry walEntry) string {
+	if entry.Relationship != nil {
+		return entry.Relationship.ID
+	}
+	return ""
+}
+
+// loadSnapshotFile restores the graph from the snapshot at the given path,
+// treating a missing file as an empty graph.
+func (g *Graph) loadSnapshotFile(path string) error {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	defer file.Close()
+	loaded, err := Load(file)
+	if err != nil {
+		return fmt.Errorf("loading snapshot: %w", err)
+	}
+	g.nodes = loaded.nodes
+	g.relationships = loaded.relationships
+	g.relsByFrom = loaded.relsByFrom
+	g.relsByTo = loaded.relsByTo
+	g.nodesByLabel = loaded.nodesByLabel
+	g.nodesByName = loaded.nodesByName
+	return nil
+}
+
+// replayWAL applies every operation in the log at the given path, treating a
+// missing file as an empty log.
+func (g *Graph) replayWAL(path string) error {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	defer file.Close()
+	decoder := json.NewDecoder(file)
+	for {
+		var entry walEntry
+		if err := decoder.Decode(&entry); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("replaying wal: %w", err)
+		}
+		g.applyWALEntry(entry)
+	}
+}
+
+// applyWALEntry replays a single logged operation against the in-memory
+// state.
+func (g *Graph) applyWALEntry(entry walEntry) {
+	switch entry.Op {
+	case opInsertNode, opUpdateNode:
+		if entry.Node != nil {
+			g.putNode(*entry.Node)
+		}
+	case opDeleteNode:
+		node, ok := g.nodes[entry.ID]
+		if !ok {
+			return
+		}
+		for relID := range g.relsByFrom[entry.ID] {
+			g.removeRelationship(g.relationships[relID])
+		}
+		for relID := range g.relsByTo[entry.ID] {
+			g.removeRelationship(g.relationships[relID])
+		}
+		g.removeNode(node)
+	case opAddRelationship, opUpdateRelationship:
+		if entry.Relationship != nil {
+			g.putRelationship(*entry.Relationship)
+		}
+	case opDeleteRelationship:
+		if rel, ok := g.relationships[entry.ID]; ok {
+			g.removeRelationship(rel)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package assets
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestOpenGraphSurvivesReopen(t *testing.T) {
+	dir := t.TempDir()
+	g, err := OpenGraph(dir)
+	if err != nil {
+		t.Fatalf("OpenGraph: %v", err)
+	}
+	web, err := g.AddNode("web-01", "server", []byte(`{"cpu":4}`))
+	if err != nil {
+		t.Fatalf("AddNode: %v", err)
+	}
+	vpc, _ := g.AddNode("vpc-1", "network", nil)
+	if _, err := g.AddRelationship(web, vpc, "in-network"); err != nil {
+		t.Fatalf("AddRelationship: %v", err)
+	}
+	if _, err := g.UpdateNode(web.ID, []byte(`{"cpu":8}`)); err != nil {
+		t.Fatalf("UpdateNode: %v", err)
+	}
+	if err := g.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	reopened, err := OpenGraph(dir)
+	if err != nil {
+		t.Fatalf("reopening graph: %v", err)
+	}
+	defer reopened.Close()
+	node, err := reopened.GetNodeByID(web.ID)
+	if err != nil {
+		t.Fatalf("GetNodeByID after reopen: %v", err)
+	}
+	if string(node.Body) != `{"cpu":8}` {
+		t.Errorf("replayed body = %s, want the updated body", node.Body)
+	}
+	if !reopened.HasRelationship(web.ID, vpc.ID, "in-network") {
+		t.Error("relationship lost across reopen")
+	}
+}
+
+func TestOpenGraphReplaysDeletes(t *testing.T) {
+	dir := t.TempDir()
+	g, err := OpenGraph(dir)
+	if err != nil {
+		t.Fatalf("OpenGraph: %v", err)
+	}
+	node, _ := g.AddNode("web-01", "server", nil)
+	if err := g.DeleteNode(node.ID); err != nil {
+		t.Fatalf("DeleteNode: %v", err)
+	}
+	if err := g.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	reopened, err := OpenGraph(dir)
+	if err != nil {
+		t.Fatalf("reopening graph: %v", err)
+	}
+	defer reopened.Close()
+	if _, err := reopened.GetNodeByID(node.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("deleted node came back after replay: %v", err)
+	}
+}
+
+func TestCompact(t *testing.T) {
+	dir := t.TempDir()
+	g, err := OpenGraph(dir)
+	if err != nil {
+		t.Fatalf("OpenGraph: %v", err)
+	}
+	node, _ := g.AddNode("web-01", "server", nil)
+	for i := 0; i < 10; i++ {
+		if _, err := g.UpdateNode(node.ID, []byte(`{"round":`+string(rune('0'+i))+`}`)); err != nil {
+			t.Fatalf("UpdateNode: %v", err)
+		}
+	}
+	if err := g.Compact(); err != nil {
+		t.Fatalf("Compact: %v", err)
+	}
+	if err := g.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	reopened, err := OpenGraph(dir)
+	if err != nil {
+		t.Fatalf("reopening compacted graph: %v", err)
+	}
+	defer reopened.Close()
+	got, err := reopened.GetNodeByID(node.ID)
+	if err != nil {
+		t.Fatalf("GetNodeByID after compaction: %v", err)
+	}
+	if string(got.Body) != `{"round":9}` {
+		t.Errorf("compacted body = %s, want the last update", got.Body)
+	}
+}
@@ -0,0 +1,141 @@
+// Package webhook posts graph change events to HTTP endpoints, so a
+// deletion of a production database node can page a human without the
+// consumer embedding Go code. Each hook pairs a URL with an optional event
+// filter and retries failed deliveries with exponential backoff.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/mimatache/asset-graph/assets"
+	"github.com/mimatache/asset-graph/assets/cdc"
+)
+
+// Option configures a hook.
+type Option func(*Hook)
+
+// WithFilter makes the hook fire only for events the given predicate
+// accepts, e.g. only deletions of nodes labeled "production-db".
+func WithFilter(filter func(cdc.Event) bool) Option {
+	return func(h *Hook) {
+		h.filter = filter
+	}
+}
+
+// WithRetries sets how many times a failed delivery is retried. The default
+// is 3.
+func WithRetries(n int) Option {
+	return func(h *Hook) {
+		h.retries = n
+	}
+}
+
+// WithBackoff sets the delay before the first retry; each further retry
+// doubles it. The default is one second.
+func WithBackoff(d time.Duration) Option {
+	return func(h *Hook) {
+		h.backoff = d
+	}
+}
+
+// WithClient sets the HTTP client used for deliveries.
+func WithClient(client *http.Client) Option {
+	return func(h *Hook) {
+		h.client = client
+	}
+}
+
+// Hook delivers matching change events to one URL. It implements
+// cdc.Publisher, so it can also be attached directly with cdc.Attach.
+type Hook struct {
+	url     string
+	filter  func(cdc.Event) bool
+	retries int
+	backoff time.Duration
+	client  *http.Client
+}
+
+// New returns a hook posting events to the given URL.
+func New(url string, opts ...Option) *Hook {
+	hook := &Hook{
+		url:     url,
+		retries: 3,
+		backoff: time.Second,
+		client:  http.DefaultClient,
+	}
+	for _, opt := range opts {
+		opt(hook)
+	}
+	return hook
+}
+
+// Publish implements cdc.Publisher: events passing the filter are POSTed as
+// JSON, retrying with exponential backoff until delivery succeeds or the
+// retries are exhausted.
+func (h *Hook) Publish(ctx context.Context, event cdc.Event) error {
+	if h.filter != nil && !h.filter(event) {
+		return nil
+	}
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("encoding webhook payload: %w", err)
+	}
+	delay := h.backoff
+	var lastErr error
+	for attempt := 0; attempt <= h.retries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-time.After(delay):
+				delay *= 2
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		lastErr = h.deliver(ctx, payload)
+		if lastErr == nil {
+			return nil
+		}
+	}
+	return fmt.Errorf("delivering webhook to %s: %w", h.url, lastErr)
+}
+
+func (h *Hook) deliver(ctx context.Context, payload []byte) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, h.url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	response, err := h.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return fmt.Errorf("unexpected status %s", response.Status)
+	}
+	return nil
+}
+
+// Attach wires one or more hooks onto a graph through a cdc stream. Events
+// are offered to every hook; each applies its own filter and retry policy.
+func Attach(g *assets.Graph, hooks ...*Hook) *cdc.Stream {
+	return cdc.Attach(g, fanout(hooks))
+}
+
+// fanout publishes an event to every hook, returning the first error.
+type fanout []*Hook
+
+func (f fanout) Publish(ctx context.Context, event cdc.Event) error {
+	var firstErr error
+	for _, hook := range f {
+		if err := hook.Publish(ctx, event); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
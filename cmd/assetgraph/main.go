@@ -0,0 +1,245 @@
+// Command assetgraph inspects persisted asset graph snapshots from the
+// terminal: list and filter nodes, print paths between two assets, export
+// diagrams, and summarize the topology — without writing Go.
+//
+// Usage:
+//
+//	assetgraph nodes  -snapshot g.json [-label l] [-name n] [-name-regex re]
+//	assetgraph path   -snapshot g.json -from a -to b [-rel label]
+//	assetgraph export -snapshot g.json [-format dot|mermaid|graphml|cypher|jsonl]
+//	assetgraph stats  -snapshot g.json
+//	assetgraph search -snapshot g.json -query terms
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/mimatache/asset-graph/assets"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+	var err error
+	switch os.Args[1] {
+	case "nodes":
+		err = runNodes(os.Args[2:])
+	case "path":
+		err = runPath(os.Args[2:])
+	case "export":
+		err = runExport(os.Args[2:])
+	case "stats":
+		err = runStats(os.Args[2:])
+	case "search":
+		err = runSearch(os.Args[2:])
+	default:
+		usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "assetgraph:", err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: assetgraph <nodes|path|export|stats|search> -snapshot <file> [flags]")
+}
+
+// loadGraph restores the snapshot named by the -snapshot flag.
+func loadGraph(path string) (*assets.Graph, error) {
+	if path == "" {
+		return nil, fmt.Errorf("-snapshot is required")
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return assets.Load(f)
+}
+
+func runNodes(args []string) error {
+	fs := flag.NewFlagSet("nodes", flag.ExitOnError)
+	snapshot := fs.String("snapshot", "", "snapshot file to load")
+	label := fs.String("label", "", "only nodes with this label")
+	name := fs.String("name", "", "only nodes with this name")
+	nameRegex := fs.String("name-regex", "", "only nodes whose name matches this regexp")
+	showBody := fs.Bool("body", false, "print node bodies")
+	fs.Parse(args)
+	g, err := loadGraph(*snapshot)
+	if err != nil {
+		return err
+	}
+	filters := []assets.Filter{}
+	if *label != "" {
+		filters = append(filters, assets.FilterNodesByLabel(*label))
+	}
+	if *name != "" {
+		filters = append(filters, assets.FilterNodesByName(*name))
+	}
+	if *nameRegex != "" {
+		filters = append(filters, assets.FilterNodesByNameRegex(*nameRegex))
+	}
+	for _, node := range g.ListNodes(filters...) {
+		fmt.Printf("%s\t%s\t%s\n", node.ID, node.Label, node.Name)
+		if *showBody && len(node.Body) > 0 {
+			fmt.Printf("\t%s\n", node.Body)
+		}
+	}
+	return nil
+}
+
+func runPath(args []string) error {
+	fs := flag.NewFlagSet("path", flag.ExitOnError)
+	snapshot := fs.String("snapshot", "", "snapshot file to load")
+	from := fs.String("from", "", "start node ID or name")
+	to := fs.String("to", "", "end node ID or name")
+	rel := fs.String("rel", "", "only traverse relationships with this label")
+	fs.Parse(args)
+	g, err := loadGraph(*snapshot)
+	if err != nil {
+		return err
+	}
+	fromNode, err := resolveNode(g, *from)
+	if err != nil {
+		return err
+	}
+	toNode, err := resolveNode(g, *to)
+	if err != nil {
+		return err
+	}
+	opts := []assets.TraversalOption{}
+	if *rel != "" {
+		opts = append(opts, assets.WithRelFilters(assets.FilterRelByLabel(*rel)))
+	}
+	chain, err := g.ShortestPath(fromNode, toNode, opts...)
+	if err != nil {
+		return err
+	}
+	fmt.Println(chain)
+	return nil
+}
+
+func runExport(args []string) error {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	snapshot := fs.String("snapshot", "", "snapshot file to load")
+	format := fs.String("format", "dot", "output format: dot, mermaid, graphml, cypher or jsonl")
+	fs.Parse(args)
+	g, err := loadGraph(*snapshot)
+	if err != nil {
+		return err
+	}
+	switch *format {
+	case "dot":
+		return writeDOT(g)
+	case "mermaid":
+		fmt.Print(g.ToMermaid())
+		return nil
+	case "graphml":
+		return g.ExportGraphML(os.Stdout)
+	case "cypher":
+		return g.ExportCypher(os.Stdout)
+	case "jsonl":
+		return g.ExportJSONL(os.Stdout)
+	default:
+		return fmt.Errorf("unknown format %q", *format)
+	}
+}
+
+// writeDOT renders the graph in graphviz dot syntax on stdout.
+func writeDOT(g *assets.Graph) error {
+	nodes := g.ListNodes()
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].ID < nodes[j].ID })
+	fmt.Println("digraph assets {")
+	for _, node := range nodes {
+		fmt.Printf("  %q [label=%q];\n", node.ID, node.Name+"\n("+node.Label+")")
+	}
+	rels := g.ListRelationships()
+	sort.Slice(rels, func(i, j int) bool { return rels[i].ID < rels[j].ID })
+	for _, rel := range rels {
+		fmt.Printf("  %q -> %q [label=%q];\n", rel.From, rel.To, rel.Label)
+	}
+	fmt.Println("}")
+	return nil
+}
+
+func runStats(args []string) error {
+	fs := flag.NewFlagSet("stats", flag.ExitOnError)
+	snapshot := fs.String("snapshot", "", "snapshot file to load")
+	fs.Parse(args)
+	g, err := loadGraph(*snapshot)
+	if err != nil {
+		return err
+	}
+	stats := g.Stats()
+	fmt.Printf("nodes:          %d\n", stats.Nodes)
+	fmt.Printf("relationships:  %d\n", stats.Relationships)
+	fmt.Printf("isolated nodes: %d\n", stats.IsolatedNodes)
+	fmt.Println("nodes per label:")
+	for _, label := range sortedKeys(stats.NodesPerLabel) {
+		fmt.Printf("  %s: %d\n", label, stats.NodesPerLabel[label])
+	}
+	fmt.Println("relationships per label:")
+	for _, label := range sortedKeys(stats.RelationshipsPerLabel) {
+		fmt.Printf("  %s: %d\n", label, stats.RelationshipsPerLabel[label])
+	}
+	return nil
+}
+
+func runSearch(args []string) error {
+	fs := flag.NewFlagSet("search", flag.ExitOnError)
+	snapshot := fs.String("snapshot", "", "snapshot file to load")
+	query := fs.String("query", "", "search terms")
+	fs.Parse(args)
+	g, err := loadGraph(*snapshot)
+	if err != nil {
+		return err
+	}
+	if *query == "" {
+		return fmt.Errorf("-query is required")
+	}
+	for _, node := range g.Search(*query) {
+		fmt.Printf("%s\t%s\t%s\n", node.ID, node.Label, node.Name)
+	}
+	return nil
+}
+
+// resolveNode accepts either a node ID or a unique node name.
+func resolveNode(g *assets.Graph, ref string) (assets.Node, error) {
+	if ref == "" {
+		return assets.Node{}, fmt.Errorf("-from and -to are required")
+	}
+	if node, err := g.GetNodeByID(ref); err == nil {
+		return node, nil
+	}
+	matches := g.GetNodesByName(ref)
+	switch len(matches) {
+	case 0:
+		return assets.Node{}, fmt.Errorf("no node with ID or name %q", ref)
+	case 1:
+		return matches[0], nil
+	default:
+		labels := make([]string, 0, len(matches))
+		for _, match := range matches {
+			labels = append(labels, match.Label)
+		}
+		return assets.Node{}, fmt.Errorf("name %q is ambiguous across labels %s; use the node ID", ref, strings.Join(labels, ", "))
+	}
+}
+
+// sortedKeys returns the map's keys in lexical order for stable output.
+func sortedKeys(m map[string]int) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,3 @@
+module github.com/mimatache/asset-graph
+
+go 1.21.6
@@ -0,0 +1,311 @@
+// Package graphql exposes an asset graph over HTTP as a GraphQL endpoint.
+// Labels become queryable through the nodes field, relationships become the
+// out and in fields, and nested selections translate directly to traversals,
+// so consumers do not have to hand-roll traversal code.
+//
+// The endpoint implements the part of GraphQL an asset graph needs: the
+// root fields
+//
+//	node(id: "...")
+//	nodes(label: "...", name: "...")
+//
+// and on every node the fields id, name, label, body, plus the traversal
+// fields out(label: "...") and in(label: "..."), each yielding nodes again
+// at arbitrary depth.
+package graphql
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/mimatache/asset-graph/assets"
+)
+
+// Handler serves GraphQL queries against a graph.
+type Handler struct {
+	graph *assets.Graph
+}
+
+// NewHandler returns an http.Handler answering GraphQL queries against the
+// given graph. Queries are accepted as the query field of a JSON POST body
+// or as the query URL parameter.
+func NewHandler(graph *assets.Graph) *Handler {
+	return &Handler{graph: graph}
+}
+
+// ServeHTTP implements http.Handler.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query().Get("query")
+	if r.Method == http.MethodPost {
+		var body struct {
+			Query string `json:"query"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeErrors(w, http.StatusBadRequest, err)
+			return
+		}
+		query = body.Query
+	}
+	data, err := h.Execute(query)
+	if err != nil {
+		writeErrors(w, http.StatusBadRequest, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{"data": data})
+}
+
+func writeErrors(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"errors": []map[string]string{{"message": err.Error()}},
+	})
+}
+
+// Execute runs a query and returns the data object of the response.
+func (h *Handler) Execute(query string) (map[string]interface{}, error) {
+	selection, err := parseQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	data := map[string]interface{}{}
+	for _, f := range selection {
+		switch f.name {
+		case "node":
+			node, err := h.graph.GetNodeByID(f.args["id"])
+			if err != nil {
+				return nil, fmt.Errorf("node %q: %w", f.args["id"], err)
+			}
+			data[f.name] = h.resolveNode(node, f.selection)
+		case "nodes":
+			filters := []assets.Filter{}
+			if label, ok := f.args["label"]; ok {
+				filters = append(filters, assets.FilterNodesByLabel(label))
+			}
+			if name, ok := f.args["name"]; ok {
+				filters = append(filters, assets.FilterNodesByName(name))
+			}
+			results := []interface{}{}
+			for _, node := range h.graph.ListNodes(filters...) {
+				results = append(results, h.resolveNode(node, f.selection))
+			}
+			data[f.name] = results
+		default:
+			return nil, fmt.Errorf("unknown root field %q", f.name)
+		}
+	}
+	return data, nil
+}
+
+func (h *Handler) resolveNode(node assets.Node, selection []field) map[string]interface{} {
+	result := map[string]interface{}{}
+	for _, f := range selection {
+		switch f.name {
+		case "id":
+			result[f.name] = node.ID
+		case "name":
+			result[f.name] = node.Name
+		case "label":
+			result[f.name] = node.Label
+		case "body":
+			result[f.name] = string(node.Body)
+		case "out", "in":
+			opts := []assets.TraversalOption{}
+			if f.name == "in" {
+				opts = append(opts, assets.WithDirection(assets.Incoming))
+			}
+			if label, ok := f.args["label"]; ok {
+				opts = append(opts, assets.WithRelFilters(assets.FilterRelByLabel(label)))
+			}
+			neighbors := []interface{}{}
+			for _, neighbor := range h.graph.Neighbors(node.ID, opts...) {
+				neighbors = append(neighbors, h.resolveNode(neighbor, f.selection))
+			}
+			result[f.name] = neighbors
+		default:
+			result[f.name] = nil
+		}
+	}
+	return result
+}
+
+// field is one entry of a GraphQL selection set.
+type field struct {
+	name      string
+	args      map[string]string
+	selection []field
+}
+
+// parseQuery parses a GraphQL query document down to nested selection sets
+// with string arguments.
+func parseQuery(query string) ([]field, error) {
+	p := &parser{input: query}
+	p.skipSpace()
+	// An optional operation header such as "query" or "query Name" may
+	// precede the selection set.
+	if p.peek() != '{' {
+		p.readName()
+		p.skipSpace()
+		if p.peek() != '{' {
+			p.readName()
+			p.skipSpace()
+		}
+	}
+	selection, err := p.parseSelectionSet()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	if !p.done() {
+		return nil, fmt.Errorf("unexpected trailing input at offset %d", p.pos)
+	}
+	return selection, nil
+}
+
+type parser struct {
+	input string
+	pos   int
+}
+
+func (p *parser) parseSelectionSet() ([]field, error) {
+	if err := p.expect('{'); err != nil {
+		return nil, err
+	}
+	fields := []field{}
+	for {
+		p.skipSpace()
+		if p.peek() == '}' {
+			p.pos++
+			if len(fields) == 0 {
+				return nil, fmt.Errorf("empty selection set at offset %d", p.pos)
+			}
+			return fields, nil
+		}
+		f, err := p.parseField()
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, f)
+	}
+}
+
+func (p *parser) parseField() (field, error) {
+	name := p.readName()
+	if name == "" {
+		return field{}, fmt.Errorf("expected field name at offset %d", p.pos)
+	}
+	f := field{name: name, args: map[string]string{}}
+	p.skipSpace()
+	if p.peek() == '(' {
+		p.pos++
+		for {
+			p.skipSpace()
+			if p.peek() == ')' {
+				p.pos++
+				break
+			}
+			if p.peek() == ',' {
+				p.pos++
+				continue
+			}
+			arg := p.readName()
+			if arg == "" {
+				return field{}, fmt.Errorf("expected argument name at offset %d", p.pos)
+			}
+			p.skipSpace()
+			if err := p.expect(':'); err != nil {
+				return field{}, err
+			}
+			p.skipSpace()
+			value, err := p.readValue()
+			if err != nil {
+				return field{}, err
+			}
+			f.args[arg] = value
+		}
+		p.skipSpace()
+	}
+	if p.peek() == '{' {
+		selection, err := p.parseSelectionSet()
+		if err != nil {
+			return field{}, err
+		}
+		f.selection = selection
+	}
+	return f, nil
+}
+
+func (p *parser) readValue() (string, error) {
+	if p.peek() == '"' {
+		end := p.pos + 1
+		for end < len(p.input) {
+			if p.input[end] == '\\' {
+				end += 2
+				continue
+			}
+			if p.input[end] == '"' {
+				value, err := strconv.Unquote(p.input[p.pos : end+1])
+				if err != nil {
+					return "", fmt.Errorf("bad string at offset %d: %w", p.pos, err)
+				}
+				p.pos = end + 1
+				return value, nil
+			}
+			end++
+		}
+		return "", fmt.Errorf("unterminated string at offset %d", p.pos)
+	}
+	if value := p.readName(); value != "" {
+		return value, nil
+	}
+	return "", fmt.Errorf("expected value at offset %d", p.pos)
+}
+
+func (p *parser) readName() string {
+	start := p.pos
+	for p.pos < len(p.input) {
+		c := rune(p.input[p.pos])
+		if unicode.IsLetter(c) || unicode.IsDigit(c) || c == '_' {
+			p.pos++
+			continue
+		}
+		break
+	}
+	return p.input[start:p.pos]
+}
+
+func (p *parser) expect(c byte) error {
+	if p.peek() != c {
+		return fmt.Errorf("expected %q at offset %d", string(c), p.pos)
+	}
+	p.pos++
+	return nil
+}
+
+func (p *parser) peek() byte {
+	if p.done() {
+		return 0
+	}
+	return p.input[p.pos]
+}
+
+func (p *parser) skipSpace() {
+	for p.pos < len(p.input) && (unicode.IsSpace(rune(p.input[p.pos])) || p.input[p.pos] == ',') {
+		p.pos++
+	}
+	if strings.HasPrefix(p.input[p.pos:], "#") {
+		for p.pos < len(p.input) && p.input[p.pos] != '\n' {
+			p.pos++
+		}
+		p.skipSpace()
+	}
+}
+
+func (p *parser) done() bool {
+	return p.pos >= len(p.input)
+}
@@ -0,0 +1,234 @@
+// Package grpcservice implements the handler logic for the AssetGraph
+// service defined in proto/assetgraph/v1/assetgraph.proto, written against
+// plain request and response structs that mirror the protobuf messages.
+//
+// This package is NOT a wire-ready gRPC server: the module deliberately
+// carries no dependencies, so the generated bindings and the grpc.Server
+// registration cannot live here. To serve the API over gRPC, generate the
+// bindings in your own module with
+//
+//	protoc --go_out=. --go-grpc_out=. proto/assetgraph/v1/assetgraph.proto
+//
+// implement the generated AssetGraphServer interface with a thin adapter
+// that converts between the generated message types and the structs in this
+// package, and delegate each RPC to the matching Service method. Until that
+// adapter exists the service is usable in-process only.
+package grpcservice
+
+import (
+	"context"
+
+	"github.com/mimatache/asset-graph/assets"
+)
+
+// Direction mirrors assetgraph.v1.Direction.
+type Direction int32
+
+const (
+	DirectionOutgoing Direction = iota
+	DirectionIncoming
+	DirectionUndirected
+)
+
+// Node mirrors assetgraph.v1.Node.
+type Node struct {
+	ID    string
+	Name  string
+	Label string
+	Body  []byte
+}
+
+// Relationship mirrors assetgraph.v1.Relationship.
+type Relationship struct {
+	ID    string
+	From  string
+	To    string
+	Label string
+	Body  []byte
+}
+
+// ChainLink mirrors assetgraph.v1.ChainLink.
+type ChainLink struct {
+	Node Node
+	Next []*ChainLink
+}
+
+// InsertNodeRequest mirrors assetgraph.v1.InsertNodeRequest.
+type InsertNodeRequest struct {
+	Name  string
+	Label string
+	Body  []byte
+}
+
+// GetNodeRequest mirrors assetgraph.v1.GetNodeRequest.
+type GetNodeRequest struct {
+	ID string
+}
+
+// ListNodesRequest mirrors assetgraph.v1.ListNodesRequest. Empty fields
+// leave the corresponding filter unset.
+type ListNodesRequest struct {
+	Label string
+	Name  string
+}
+
+// AddRelationshipRequest mirrors assetgraph.v1.AddRelationshipRequest.
+type AddRelationshipRequest struct {
+	From  string
+	To    string
+	Label string
+	Body  []byte
+}
+
+// ListRelationshipsRequest mirrors assetgraph.v1.ListRelationshipsRequest.
+// Empty fields leave the corresponding filter unset.
+type ListRelationshipsRequest struct {
+	Label string
+	From  string
+	To    string
+}
+
+// TraverseRequest mirrors assetgraph.v1.TraverseRequest.
+type TraverseRequest struct {
+	StartID            string
+	Direction          Direction
+	RelationshipLabels []string
+}
+
+// Service serves an asset graph over gRPC.
+type Service struct {
+	graph *assets.Graph
+}
+
+// NewService returns a service exposing the given graph.
+func NewService(graph *assets.Graph) *Service {
+	return &Service{graph: graph}
+}
+
+// InsertNode adds a node to the graph.
+func (s *Service) InsertNode(_ context.Context, req InsertNodeRequest) (Node, error) {
+	node, err := s.graph.AddNode(req.Name, req.Label, req.Body)
+	if err != nil {
+		return Node{}, err
+	}
+	return fromNode(node), nil
+}
+
+// GetNode returns the node with the requested ID.
+func (s *Service) GetNode(_ context.Context, req GetNodeRequest) (Node, error) {
+	node, err := s.graph.GetNodeByID(req.ID)
+	if err != nil {
+		return Node{}, err
+	}
+	return fromNode(node), nil
+}
+
+// ListNodes returns the nodes matching the requested filters.
+func (s *Service) ListNodes(_ context.Context, req ListNodesRequest) ([]Node, error) {
+	filters := []assets.Filter{}
+	if req.Label != "" {
+		filters = append(filters, assets.FilterNodesByLabel(req.Label))
+	}
+	if req.Name != "" {
+		filters = append(filters, assets.FilterNodesByName(req.Name))
+	}
+	nodes := []Node{}
+	for _, node := range s.graph.ListNodes(filters...) {
+		nodes = append(nodes, fromNode(node))
+	}
+	return nodes, nil
+}
+
+// AddRelationship adds a relationship between two existing nodes.
+func (s *Service) AddRelationship(_ context.Context, req AddRelationshipRequest) (Relationship, error) {
+	from, err := s.graph.GetNodeByID(req.From)
+	if err != nil {
+		return Relationship{}, err
+	}
+	to, err := s.graph.GetNodeByID(req.To)
+	if err != nil {
+		return Relationship{}, err
+	}
+	opts := []assets.RelOption{}
+	if len(req.Body) > 0 {
+		opts = append(opts, assets.WithRelBody(req.Body))
+	}
+	rel, err := s.graph.AddRelationship(from, to, req.Label, opts...)
+	if err != nil {
+		return Relationship{}, err
+	}
+	return fromRelationship(rel), nil
+}
+
+// ListRelationships returns the relationships matching the requested filters.
+func (s *Service) ListRelationships(_ context.Context, req ListRelationshipsRequest) ([]Relationship, error) {
+	filters := []assets.FilterRelationship{}
+	if req.Label != "" {
+		filters = append(filters, assets.FilterRelByLabel(req.Label))
+	}
+	if req.From != "" {
+		filters = append(filters, assets.FilterRelByFrom(req.From))
+	}
+	if req.To != "" {
+		filters = append(filters, assets.FilterRelByTo(req.To))
+	}
+	rels := []Relationship{}
+	for _, rel := range s.graph.ListRelationships(filters...) {
+		rels = append(rels, fromRelationship(rel))
+	}
+	return rels, nil
+}
+
+// Traverse walks the graph from the requested node and returns the
+// connections found as a chain link tree.
+func (s *Service) Traverse(_ context.Context, req TraverseRequest) (*ChainLink, error) {
+	start, err := s.graph.GetNodeByID(req.StartID)
+	if err != nil {
+		return nil, err
+	}
+	opts := []assets.TraversalOption{WithServiceDirection(req.Direction)}
+	if len(req.RelationshipLabels) > 0 {
+		wanted := map[string]bool{}
+		for _, label := range req.RelationshipLabels {
+			wanted[label] = true
+		}
+		opts = append(opts, assets.WithRelFilters(func(r assets.Relationship) bool {
+			return wanted[r.Label]
+		}))
+	}
+	onPath := map[string]bool{start.ID: true}
+	return s.traverse(start, opts, onPath), nil
+}
+
+func (s *Service) traverse(current assets.Node, opts []assets.TraversalOption, onPath map[string]bool) *ChainLink {
+	link := &ChainLink{Node: fromNode(current)}
+	for _, neighbor := range s.graph.Neighbors(current.ID, opts...) {
+		if onPath[neighbor.ID] {
+			continue
+		}
+		onPath[neighbor.ID] = true
+		link.Next = append(link.Next, s.traverse(neighbor, opts, onPath))
+		delete(onPath, neighbor.ID)
+	}
+	return link
+}
+
+// WithServiceDirection converts a wire direction into a traversal option.
+func WithServiceDirection(direction Direction) assets.TraversalOption {
+	switch direction {
+	case DirectionIncoming:
+		return assets.WithDirection(assets.Incoming)
+	case DirectionUndirected:
+		return assets.WithDirection(assets.Undirected)
+	default:
+		return assets.WithDirection(assets.Outgoing)
+	}
+}
+
+func fromNode(node assets.Node) Node {
+	return Node{ID: node.ID, Name: node.Name, Label: node.Label, Body: node.Body}
+}
+
+func fromRelationship(rel assets.Relationship) Relationship {
+	return Relationship{ID: rel.ID, From: rel.From, To: rel.To, Label: rel.Label, Body: rel.Body}
+}